@@ -0,0 +1,251 @@
+package eventv1
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AttributeKindsKey is the attribute that records the type of the extensions
+// in the transports that carry the attribute values as strings. It is not a
+// ce- attribute, so it is not mistaken for an extension, and it has the same
+// name as the AttributeKindsMember of the JSON event format.
+const AttributeKindsKey = "attributekinds"
+
+// AttributeKind represents the CloudEvents type of an attribute value.
+type AttributeKind int
+
+const (
+	// AttributeKindUnknown represents an unknown attribute type.
+	AttributeKindUnknown AttributeKind = iota
+	// AttributeKindBoolean represents a Boolean attribute.
+	AttributeKindBoolean
+	// AttributeKindInteger represents an Integer attribute.
+	AttributeKindInteger
+	// AttributeKindString represents a String attribute.
+	AttributeKindString
+	// AttributeKindBinary represents a Binary attribute.
+	AttributeKindBinary
+	// AttributeKindURI represents a URI attribute.
+	AttributeKindURI
+	// AttributeKindURIRef represents a URI-reference attribute.
+	AttributeKindURIRef
+	// AttributeKindTimestamp represents a Timestamp attribute.
+	AttributeKindTimestamp
+)
+
+// String returns the CloudEvents name of the attribute type.
+func (k AttributeKind) String() string {
+	switch k {
+	case AttributeKindBoolean:
+		return "Boolean"
+	case AttributeKindInteger:
+		return "Integer"
+	case AttributeKindString:
+		return "String"
+	case AttributeKindBinary:
+		return "Binary"
+	case AttributeKindURI:
+		return "URI"
+	case AttributeKindURIRef:
+		return "URI-reference"
+	case AttributeKindTimestamp:
+		return "Timestamp"
+	default:
+		return "Unknown"
+	}
+}
+
+var (
+	kinds = map[string]AttributeKind{
+		"subject":         AttributeKindString,
+		"datacontenttype": AttributeKindString,
+		"dataschema":      AttributeKindURI,
+		"time":            AttributeKindTimestamp,
+	}
+	kindsMu sync.RWMutex
+)
+
+// RegisterAttributeKind registers the type of the named extension attribute.
+// Formats that carry attribute values as strings use it to restore the
+// original type when the event is decoded.
+func RegisterAttributeKind(name string, kind AttributeKind) {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+
+	kinds[name] = kind
+}
+
+// LookupAttributeKind returns the registered type of the named attribute.
+func LookupAttributeKind(name string) (AttributeKind, bool) {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+
+	kind, ok := kinds[name]
+	return kind, ok
+}
+
+// GetKind returns the type of the attribute value.
+func (x *EventAttributeValue) GetKind() AttributeKind {
+	switch x.GetAttr().(type) {
+	case *EventAttributeValue_CeBoolean:
+		return AttributeKindBoolean
+	case *EventAttributeValue_CeInteger:
+		return AttributeKindInteger
+	case *EventAttributeValue_CeString:
+		return AttributeKindString
+	case *EventAttributeValue_CeBytes:
+		return AttributeKindBinary
+	case *EventAttributeValue_CeUri:
+		return AttributeKindURI
+	case *EventAttributeValue_CeUriRef:
+		return AttributeKindURIRef
+	case *EventAttributeValue_CeTimestamp:
+		return AttributeKindTimestamp
+	default:
+		return AttributeKindUnknown
+	}
+}
+
+// Format returns the canonical string representation of the attribute value.
+func (x *EventAttributeValue) Format() string {
+	switch attr := x.GetAttr().(type) {
+	case *EventAttributeValue_CeBoolean:
+		return strconv.FormatBool(attr.CeBoolean)
+	case *EventAttributeValue_CeInteger:
+		return strconv.FormatInt(int64(attr.CeInteger), 10)
+	case *EventAttributeValue_CeBytes:
+		return base64.StdEncoding.EncodeToString(attr.CeBytes)
+	case *EventAttributeValue_CeUri:
+		return attr.CeUri
+	case *EventAttributeValue_CeUriRef:
+		return attr.CeUriRef
+	case *EventAttributeValue_CeTimestamp:
+		return attr.CeTimestamp.AsTime().UTC().Format(time.RFC3339Nano)
+	case *EventAttributeValue_CeString:
+		return attr.CeString
+	default:
+		return ""
+	}
+}
+
+// ParseAttributeValue parses the canonical string representation of an
+// attribute value of the given type.
+func ParseAttributeValue(kind AttributeKind, value string) (*EventAttributeValue, error) {
+	switch kind {
+	case AttributeKindBoolean:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as %v: %w", value, kind, err)
+		}
+
+		return &EventAttributeValue{Attr: &EventAttributeValue_CeBoolean{CeBoolean: v}}, nil
+	case AttributeKindInteger:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as %v: %w", value, kind, err)
+		}
+
+		return &EventAttributeValue{Attr: &EventAttributeValue_CeInteger{CeInteger: int32(v)}}, nil
+	case AttributeKindBinary:
+		v, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as %v: %w", value, kind, err)
+		}
+
+		return &EventAttributeValue{Attr: &EventAttributeValue_CeBytes{CeBytes: v}}, nil
+	case AttributeKindURI:
+		if _, err := url.Parse(value); err != nil {
+			return nil, fmt.Errorf("cannot parse %q as %v: %w", value, kind, err)
+		}
+
+		return &EventAttributeValue{Attr: &EventAttributeValue_CeUri{CeUri: value}}, nil
+	case AttributeKindURIRef:
+		if _, err := url.Parse(value); err != nil {
+			return nil, fmt.Errorf("cannot parse %q as %v: %w", value, kind, err)
+		}
+
+		return &EventAttributeValue{Attr: &EventAttributeValue_CeUriRef{CeUriRef: value}}, nil
+	case AttributeKindTimestamp:
+		v, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as %v: %w", value, kind, err)
+		}
+
+		return &EventAttributeValue{Attr: &EventAttributeValue_CeTimestamp{CeTimestamp: timestamppb.New(v)}}, nil
+	case AttributeKindString:
+		return &EventAttributeValue{Attr: &EventAttributeValue_CeString{CeString: value}}, nil
+	default:
+		return nil, fmt.Errorf("cannot parse %q as %v", value, kind)
+	}
+}
+
+// ParseAttributeKind parses the CloudEvents name of an attribute type.
+func ParseAttributeKind(value string) (AttributeKind, error) {
+	for kind := AttributeKindBoolean; kind <= AttributeKindTimestamp; kind++ {
+		if strings.EqualFold(kind.String(), value) {
+			return kind, nil
+		}
+	}
+
+	return AttributeKindUnknown, fmt.Errorf("unknown attribute type %q", value)
+}
+
+// parseAttributeKinds parses the value of the AttributeKindsKey attribute.
+func parseAttributeKinds(value string) (map[string]AttributeKind, error) {
+	kinds := make(map[string]AttributeKind)
+
+	if value == "" {
+		return kinds, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		name, text, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("cannot parse the attribute kind %q", item)
+		}
+
+		kind, err := ParseAttributeKind(text)
+		if err != nil {
+			return nil, err
+		}
+
+		kinds[strings.ToLower(name)] = kind
+	}
+
+	return kinds, nil
+}
+
+// formatAttributeKinds formats the value of the AttributeKindsKey attribute
+// for the given attributes. The attributes of the skipped types and the
+// attributes registered with their own type are omitted, since they are
+// restored without a hint.
+func formatAttributeKinds(attributes map[string]*EventAttributeValue, skip ...AttributeKind) string {
+	var items []string
+
+	for name, attribute := range attributes {
+		kind := attribute.GetKind()
+
+		switch {
+		case kind == AttributeKindUnknown, kind == AttributeKindString, slices.Contains(skip, kind):
+			continue
+		}
+
+		if registered, ok := LookupAttributeKind(name); ok && registered == kind {
+			continue
+		}
+
+		items = append(items, name+":"+kind.String())
+	}
+
+	slices.Sort(items)
+	// done!
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,98 @@
+package eventv1_test
+
+import (
+	"testing"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestParseAttributeValue(t *testing.T) {
+	cases := []struct {
+		kind  eventv1.AttributeKind
+		value string
+	}{
+		{eventv1.AttributeKindBoolean, "true"},
+		{eventv1.AttributeKindInteger, "-2147483648"},
+		{eventv1.AttributeKindString, "hello world"},
+		{eventv1.AttributeKindBinary, "AAEC/w=="},
+		{eventv1.AttributeKindURI, "https://example.com/path?q=1"},
+		{eventv1.AttributeKindURIRef, "../path"},
+		{eventv1.AttributeKindTimestamp, "2024-01-02T03:04:05.000000006Z"},
+	}
+
+	for _, item := range cases {
+		t.Run(item.kind.String(), func(t *testing.T) {
+			value, err := eventv1.ParseAttributeValue(item.kind, item.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := value.GetKind(); got != item.kind {
+				t.Errorf("got kind %v, want %v", got, item.kind)
+			}
+
+			if got := value.Format(); got != item.value {
+				t.Errorf("got %q, want %q", got, item.value)
+			}
+		})
+	}
+}
+
+func TestParseAttributeValueError(t *testing.T) {
+	cases := []struct {
+		kind  eventv1.AttributeKind
+		value string
+	}{
+		{eventv1.AttributeKindBoolean, "yes"},
+		{eventv1.AttributeKindInteger, "2147483648"},
+		{eventv1.AttributeKindBinary, "%"},
+		{eventv1.AttributeKindURI, "http://[::1"},
+		{eventv1.AttributeKindTimestamp, "2024-01-02"},
+		{eventv1.AttributeKindUnknown, "a"},
+	}
+
+	for _, item := range cases {
+		t.Run(item.kind.String(), func(t *testing.T) {
+			if _, err := eventv1.ParseAttributeValue(item.kind, item.value); err == nil {
+				t.Errorf("expected an error for %q", item.value)
+			}
+		})
+	}
+}
+
+func TestParseAttributeKind(t *testing.T) {
+	for kind := eventv1.AttributeKindBoolean; kind <= eventv1.AttributeKindTimestamp; kind++ {
+		got, err := eventv1.ParseAttributeKind(kind.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != kind {
+			t.Errorf("got %v, want %v", got, kind)
+		}
+	}
+
+	if _, err := eventv1.ParseAttributeKind("Float"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func registerTestAttributeKind(t *testing.T, name string, kind eventv1.AttributeKind) {
+	t.Helper()
+
+	eventv1.RegisterAttributeKind(name, kind)
+	t.Cleanup(func() { eventv1.UnregisterAttributeKind(name) })
+}
+
+func TestRegisterAttributeKind(t *testing.T) {
+	if _, ok := eventv1.LookupAttributeKind("testregisterkind"); ok {
+		t.Fatal("the attribute is registered")
+	}
+
+	registerTestAttributeKind(t, "testregisterkind", eventv1.AttributeKindURI)
+
+	kind, ok := eventv1.LookupAttributeKind("testregisterkind")
+	if !ok || kind != eventv1.AttributeKindURI {
+		t.Errorf("got %v, %v, want %v", kind, ok, eventv1.AttributeKindURI)
+	}
+}
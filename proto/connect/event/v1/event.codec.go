@@ -0,0 +1,188 @@
+package eventv1
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DataCodec is the interface that wraps the methods that encode and decode the
+// event data of a media type.
+type DataCodec interface {
+	// Marshal encodes the given value.
+	Marshal(value any) ([]byte, error)
+	// Unmarshal decodes the given data into the value. The value should be a pointer type.
+	Unmarshal(data []byte, value any) error
+}
+
+var (
+	// JSONDataCodec encodes the data as JSON. It uses protojson for proto messages.
+	JSONDataCodec DataCodec = &jsonDataCodec{}
+	// XMLDataCodec encodes the data as XML.
+	XMLDataCodec DataCodec = &xmlDataCodec{}
+	// ProtoDataCodec encodes the proto messages in the protobuf binary format.
+	ProtoDataCodec DataCodec = &protoDataCodec{}
+	// CBORDataCodec encodes the data as CBOR.
+	CBORDataCodec DataCodec = &cborDataCodec{}
+	// TextDataCodec encodes strings, byte slices and fmt.Stringer values as text.
+	TextDataCodec DataCodec = &textDataCodec{}
+)
+
+var (
+	codecs = map[string]DataCodec{
+		"application/json":       JSONDataCodec,
+		"text/json":              JSONDataCodec,
+		"application/xml":        XMLDataCodec,
+		"text/xml":               XMLDataCodec,
+		"application/protobuf":   ProtoDataCodec,
+		"application/x-protobuf": ProtoDataCodec,
+		"application/cbor":       CBORDataCodec,
+		"text/plain":             TextDataCodec,
+	}
+	codecsMu sync.RWMutex
+)
+
+// RegisterDataCodec registers the codec of the given media type. It replaces
+// the codec that is already registered for the media type.
+func RegisterDataCodec(mtype string, codec DataCodec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+
+	codecs[strings.ToLower(mtype)] = codec
+}
+
+// LookupDataCodec returns the codec of the given content type. The media type
+// parameters are ignored. A media type without a registered codec falls back
+// to the codec of its structured syntax suffix, so application/problem+json
+// uses the application/json codec and application/atom+xml uses the
+// application/xml codec. Any other text media type falls back to the
+// text/plain codec.
+func LookupDataCodec(ctype string) (DataCodec, bool) {
+	mtype, _, err := mime.ParseMediaType(ctype)
+	if err != nil {
+		return nil, false
+	}
+
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	if codec, ok := codecs[mtype]; ok {
+		return codec, true
+	}
+
+	if index := strings.LastIndex(mtype, "+"); index >= 0 {
+		if codec, ok := codecs["application/"+mtype[index+1:]]; ok {
+			return codec, true
+		}
+	}
+
+	if strings.HasPrefix(mtype, "text/") {
+		codec, ok := codecs["text/plain"]
+		return codec, ok
+	}
+
+	return nil, false
+}
+
+type jsonDataCodec struct{}
+
+// Marshal implements DataCodec.
+func (*jsonDataCodec) Marshal(value any) ([]byte, error) {
+	if message, ok := value.(proto.Message); ok {
+		return protojson.Marshal(message)
+	}
+
+	return json.Marshal(value)
+}
+
+// Unmarshal implements DataCodec.
+func (*jsonDataCodec) Unmarshal(data []byte, value any) error {
+	if message, ok := value.(proto.Message); ok {
+		return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, message)
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+type xmlDataCodec struct{}
+
+// Marshal implements DataCodec.
+func (*xmlDataCodec) Marshal(value any) ([]byte, error) {
+	return xml.Marshal(value)
+}
+
+// Unmarshal implements DataCodec.
+func (*xmlDataCodec) Unmarshal(data []byte, value any) error {
+	return xml.Unmarshal(data, value)
+}
+
+type protoDataCodec struct{}
+
+// Marshal implements DataCodec.
+func (*protoDataCodec) Marshal(value any) ([]byte, error) {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("cannot marshal data-type %T as protobuf", value)
+	}
+
+	return proto.Marshal(message)
+}
+
+// Unmarshal implements DataCodec.
+func (*protoDataCodec) Unmarshal(data []byte, value any) error {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal protobuf as data-type %T", value)
+	}
+
+	return proto.Unmarshal(data, message)
+}
+
+type cborDataCodec struct{}
+
+// Marshal implements DataCodec.
+func (*cborDataCodec) Marshal(value any) ([]byte, error) {
+	return cbor.Marshal(value)
+}
+
+// Unmarshal implements DataCodec.
+func (*cborDataCodec) Unmarshal(data []byte, value any) error {
+	return cbor.Unmarshal(data, value)
+}
+
+type textDataCodec struct{}
+
+// Marshal implements DataCodec.
+func (*textDataCodec) Marshal(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case fmt.Stringer:
+		return []byte(v.String()), nil
+	default:
+		return nil, fmt.Errorf("cannot marshal data-type %T as text", value)
+	}
+}
+
+// Unmarshal implements DataCodec.
+func (*textDataCodec) Unmarshal(data []byte, value any) error {
+	switch v := value.(type) {
+	case *string:
+		*v = string(data)
+	case *[]byte:
+		*v = append((*v)[:0], data...)
+	default:
+		return fmt.Errorf("cannot unmarshal text as data-type %T", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package eventv1_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+type testPayload struct {
+	Name  string `json:"name" xml:"name" cbor:"name"`
+	Count int    `json:"count" xml:"count" cbor:"count"`
+}
+
+// jsonTimestamp is a proto.Message that implements json.Marshaler.
+type jsonTimestamp struct {
+	*timestamppb.Timestamp
+}
+
+func (x jsonTimestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.AsTime())
+}
+
+type upperDataCodec struct{}
+
+func (upperDataCodec) Marshal(value any) ([]byte, error) {
+	return []byte(strings.ToUpper(value.(string))), nil
+}
+
+func (upperDataCodec) Unmarshal(data []byte, value any) error {
+	*value.(*string) = strings.ToLower(string(data))
+	return nil
+}
+
+func TestLookupDataCodec(t *testing.T) {
+	cases := []struct {
+		ctype string
+		codec eventv1.DataCodec
+	}{
+		{"application/json", eventv1.JSONDataCodec},
+		{"application/json; charset=utf-8", eventv1.JSONDataCodec},
+		{"application/problem+json", eventv1.JSONDataCodec},
+		{"application/atom+xml", eventv1.XMLDataCodec},
+		{"application/x-protobuf", eventv1.ProtoDataCodec},
+		{"application/cbor", eventv1.CBORDataCodec},
+		{"text/csv", eventv1.TextDataCodec},
+	}
+
+	for _, item := range cases {
+		t.Run(item.ctype, func(t *testing.T) {
+			codec, ok := eventv1.LookupDataCodec(item.ctype)
+			if !ok || codec != item.codec {
+				t.Errorf("got %T, %v, want %T", codec, ok, item.codec)
+			}
+		})
+	}
+
+	for _, ctype := range []string{"application/octet-stream", "", "a b"} {
+		if _, ok := eventv1.LookupDataCodec(ctype); ok {
+			t.Errorf("unexpected codec for %q", ctype)
+		}
+	}
+}
+
+func TestRegisterDataCodec(t *testing.T) {
+	eventv1.RegisterDataCodec("Application/X-Upper", upperDataCodec{})
+
+	event := newTestEvent(t)
+	if err := event.SetDataWithContentType("application/x-upper", "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := event.GetBinaryData(); string(got) != "HELLO" {
+		t.Errorf("got %s, want HELLO", got)
+	}
+
+	var value string
+	if err := event.GetDataAs(&value); err != nil || value != "hello" {
+		t.Errorf("got %q, %v, want hello", value, err)
+	}
+}
+
+func TestEventDataWithContentType(t *testing.T) {
+	cases := []string{
+		"application/json",
+		"application/xml",
+		"application/cbor",
+		"application/vnd.example+json",
+	}
+
+	for _, ctype := range cases {
+		t.Run(ctype, func(t *testing.T) {
+			want := &testPayload{Name: "a", Count: 2}
+
+			event := newTestEvent(t)
+			if err := event.SetDataWithContentType(ctype, want); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := event.GetDataContentType(); got != ctype {
+				t.Errorf("got content type %q, want %q", got, ctype)
+			}
+
+			got := &testPayload{}
+			if err := event.GetDataAs(got); err != nil {
+				t.Fatal(err)
+			}
+
+			if *got != *want {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestEventDataWithContentTypeText(t *testing.T) {
+	event := newTestEvent(t)
+	if err := event.SetDataWithContentType("text/csv", "a,b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := event.GetTextData(); got != "a,b" {
+		t.Errorf("got text %q, want a,b", got)
+	}
+
+	var raw []byte
+	if err := event.GetDataAs(&raw); err != nil || string(raw) != "a,b" {
+		t.Errorf("got %s, %v, want a,b", raw, err)
+	}
+}
+
+func TestEventDataWithContentTypeBytes(t *testing.T) {
+	cases := []struct {
+		ctype string
+		data  string
+	}{
+		{"application/json", `{"id":1}`},
+		{"application/problem+json", `{"title":"a"}`},
+		{"text/plain", "hello"},
+	}
+
+	for _, item := range cases {
+		t.Run(item.ctype, func(t *testing.T) {
+			event := newTestEvent(t)
+			if err := event.SetDataWithContentType(item.ctype, []byte(item.data)); err != nil {
+				t.Fatal(err)
+			}
+
+			var got string
+
+			switch data := event.GetData().(type) {
+			case *eventv1.Event_BinaryData:
+				got = string(data.BinaryData)
+			case *eventv1.Event_TextData:
+				got = data.TextData
+			}
+
+			// the data is stored as it is, not encoded again
+			if got != item.data {
+				t.Errorf("got data %q, want %q", got, item.data)
+			}
+		})
+	}
+}
+
+func TestEventDataWithContentTypeProto(t *testing.T) {
+	want := timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	cases := []struct {
+		ctype string
+		value proto.Message
+	}{
+		{"application/protobuf", want},
+		{"application/json", want},
+		{eventv1.ContentTypeCloudEventsProtobuf, want},
+		{eventv1.ContentTypeCloudEventsProtobuf, jsonTimestamp{want}},
+	}
+
+	for _, item := range cases {
+		t.Run(item.ctype, func(t *testing.T) {
+			event := newTestEvent(t)
+			if err := event.SetDataWithContentType(item.ctype, item.value); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := event.GetDataSchema(); got != "type.googleapis.com/google.protobuf.Timestamp" {
+				t.Errorf("got data schema %q", got)
+			}
+
+			got := &timestamppb.Timestamp{}
+			if err := event.GetDataAs(got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !proto.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEventDataWithContentTypeCloudEventsProtobuf(t *testing.T) {
+	value := jsonTimestamp{timestamppb.New(time.Unix(10, 0))}
+
+	event := newTestEvent(t)
+	if err := event.SetDataWithContentType(eventv1.ContentTypeCloudEventsProtobuf, value); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := event.GetData().(*eventv1.Event_ProtoData); !ok {
+		t.Errorf("got %T, want ProtoData", event.GetData())
+	}
+
+	if got := event.GetDataContentType(); got != eventv1.ContentTypeCloudEventsProtobuf {
+		t.Errorf("got content type %q, want %q", got, eventv1.ContentTypeCloudEventsProtobuf)
+	}
+
+	entity, _ := anypb.New(value.Timestamp)
+
+	if err := event.SetDataWithContentType(eventv1.ContentTypeCloudEventsProtobuf, entity); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := event.GetProtoData(); got != entity {
+		t.Errorf("got %v, want the given Any", got)
+	}
+
+	if err := event.SetDataWithContentType(eventv1.ContentTypeCloudEventsProtobuf, "text"); err == nil {
+		t.Error("expected an error for a non-proto value")
+	}
+}
+
+func TestEventDataWithContentTypeError(t *testing.T) {
+	event := newTestEvent(t)
+
+	if err := event.SetDataWithContentType("application/octet-stream", "a"); err == nil {
+		t.Error("expected an error for a content type without a codec")
+	}
+
+	if err := event.SetDataWithContentType("application/protobuf", "a"); err == nil {
+		t.Error("expected an error for a non-proto value")
+	}
+
+	if err := event.SetDataWithContentType("text/plain", 42); err == nil {
+		t.Error("expected an error for a non-text value")
+	}
+}
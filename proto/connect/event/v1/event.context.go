@@ -0,0 +1,149 @@
+package eventv1
+
+import (
+	"context"
+	"time"
+)
+
+// EventContext represents the context attributes of the event that is being
+// handled.
+type EventContext struct {
+	// ID is the id attribute.
+	ID string
+	// Source is the source attribute.
+	Source string
+	// SpecVersion is the specversion attribute.
+	SpecVersion string
+	// Type is the type attribute.
+	Type string
+	// Subject is the subject attribute.
+	Subject string
+	// Time is the time attribute.
+	Time time.Time
+	// DataSchema is the dataschema attribute.
+	DataSchema string
+	// DataContentType is the datacontenttype attribute.
+	DataContentType string
+	// Extensions contains the extension attributes.
+	Extensions map[string]*EventAttributeValue
+	// Attempt is the number of the current attempt to handle the event,
+	// starting from 1.
+	Attempt int
+}
+
+// NewEventContext returns the context attributes of the given event.
+func NewEventContext(event *Event) *EventContext {
+	ectx := &EventContext{
+		ID:              event.GetId(),
+		Source:          event.GetSource(),
+		SpecVersion:     event.GetSpecVersion(),
+		Type:            event.GetType(),
+		Subject:         event.GetSubject(),
+		Time:            event.GetTime(),
+		DataSchema:      event.GetDataSchema(),
+		DataContentType: event.GetDataContentType(),
+		Extensions:      make(map[string]*EventAttributeValue),
+		Attempt:         1,
+	}
+
+	for name, value := range event.Extensions() {
+		ectx.Extensions[name] = value
+	}
+
+	return ectx
+}
+
+type eventContextKey struct{}
+
+// NewContext returns a new context that carries the given event context.
+func NewContext(ctx context.Context, ectx *EventContext) context.Context {
+	return context.WithValue(ctx, eventContextKey{}, ectx)
+}
+
+// FromContext returns the event context carried by the given context.
+func FromContext(ctx context.Context) (*EventContext, bool) {
+	ectx, ok := ctx.Value(eventContextKey{}).(*EventContext)
+	return ectx, ok
+}
+
+// GetEventID returns the event id from the context.
+func GetEventID(ctx context.Context) string {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.ID
+	}
+
+	return ""
+}
+
+// GetEventType returns the event type from the context.
+func GetEventType(ctx context.Context) string {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.Type
+	}
+
+	return ""
+}
+
+// GetEventSource returns the event source from the context.
+func GetEventSource(ctx context.Context) string {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.Source
+	}
+
+	return ""
+}
+
+// GetEventSubject returns the event subject from the context.
+func GetEventSubject(ctx context.Context) string {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.Subject
+	}
+
+	return ""
+}
+
+// GetEventDataSchema returns the event data schema from the context.
+func GetEventDataSchema(ctx context.Context) string {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.DataSchema
+	}
+
+	return ""
+}
+
+// GetEventDataContentType returns the event data content type from the context.
+func GetEventDataContentType(ctx context.Context) string {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.DataContentType
+	}
+
+	return ""
+}
+
+// GetEventSpecVersion returns the event spec version from the context.
+func GetEventSpecVersion(ctx context.Context) string {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.SpecVersion
+	}
+
+	return ""
+}
+
+// GetEventTime returns the event time from the context.
+func GetEventTime(ctx context.Context) time.Time {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.Time
+	}
+
+	return time.Time{}
+}
+
+// GetEventAttempt returns the number of the current attempt to handle the event
+// from the context.
+func GetEventAttempt(ctx context.Context) int {
+	if ectx, ok := FromContext(ctx); ok {
+		return ectx.Attempt
+	}
+
+	return 0
+}
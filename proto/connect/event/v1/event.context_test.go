@@ -0,0 +1,69 @@
+package eventv1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestNewEventContext(t *testing.T) {
+	event := newTestEvent(t)
+	event.SetDataSchema("https://example.com/schema")
+	event.SetDataContentType("application/json")
+	_ = event.SetExtension("ext", 1)
+
+	ctx := eventv1.NewContext(context.Background(), eventv1.NewEventContext(event))
+
+	cases := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"id", eventv1.GetEventID(ctx), event.GetId()},
+		{"type", eventv1.GetEventType(ctx), event.GetType()},
+		{"source", eventv1.GetEventSource(ctx), event.GetSource()},
+		{"subject", eventv1.GetEventSubject(ctx), event.GetSubject()},
+		{"spec version", eventv1.GetEventSpecVersion(ctx), eventv1.SpecVersion},
+		{"data schema", eventv1.GetEventDataSchema(ctx), "https://example.com/schema"},
+		{"data content type", eventv1.GetEventDataContentType(ctx), "application/json"},
+		{"time", eventv1.GetEventTime(ctx), event.GetTime()},
+		{"attempt", eventv1.GetEventAttempt(ctx), 1},
+	}
+
+	for _, item := range cases {
+		if item.got != item.want {
+			t.Errorf("got %v %v, want %v", item.name, item.got, item.want)
+		}
+	}
+
+	ectx, ok := eventv1.FromContext(ctx)
+	if !ok {
+		t.Fatal("missing event context")
+	}
+
+	if len(ectx.Extensions) != 1 || ectx.Extensions["ext"].GetCeInteger() != 1 {
+		t.Errorf("got extensions %v, want only ext", ectx.Extensions)
+	}
+}
+
+func TestFromContextMissing(t *testing.T) {
+	ctx := context.Background()
+
+	if _, ok := eventv1.FromContext(ctx); ok {
+		t.Error("unexpected event context")
+	}
+
+	if got := eventv1.GetEventID(ctx); got != "" {
+		t.Errorf("got id %q, want none", got)
+	}
+
+	if got := eventv1.GetEventTime(ctx); !got.Equal(time.Time{}) {
+		t.Errorf("got time %v, want none", got)
+	}
+
+	if got := eventv1.GetEventAttempt(ctx); got != 0 {
+		t.Errorf("got attempt %d, want 0", got)
+	}
+}
@@ -0,0 +1,133 @@
+package eventv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSkip is returned by Skip when it is called with a nil error.
+var ErrSkip = fmt.Errorf("skip")
+
+// ErrorKind classifies the errors returned by an EventHandler.
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown represents an unclassified error.
+	ErrorKindUnknown ErrorKind = iota
+	// ErrorKindRetryable represents a transient failure. The event should be
+	// delivered again.
+	ErrorKindRetryable
+	// ErrorKindPermanent represents a failure that a new delivery of the event
+	// cannot fix. The event should be acknowledged.
+	ErrorKindPermanent
+	// ErrorKindSkip represents an event that the handler ignores on purpose.
+	// The event should be acknowledged.
+	ErrorKindSkip
+)
+
+// String returns the name of the error kind.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindRetryable:
+		return "retryable"
+	case ErrorKindPermanent:
+		return "permanent"
+	case ErrorKindSkip:
+		return "skip"
+	default:
+		return "unknown"
+	}
+}
+
+// HandlerError represents a classified error of an EventHandler.
+type HandlerError struct {
+	// Kind is the error kind.
+	Kind ErrorKind
+	// Err is the underlying error.
+	Err error
+	// RetryAfter is the suggested delay before the next delivery of a
+	// retryable event. Zero means no suggestion.
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e *HandlerError) Error() string {
+	if e.Err == ErrSkip {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent classifies the error as permanent.
+func Permanent(err error) error {
+	return &HandlerError{Kind: ErrorKindPermanent, Err: err}
+}
+
+// Retryable classifies the error as retryable with the suggested delay before
+// the next delivery.
+func Retryable(err error, after time.Duration) error {
+	return &HandlerError{Kind: ErrorKindRetryable, Err: err, RetryAfter: after}
+}
+
+// Skip classifies the error as a skipped event. The error describes the reason
+// and may be nil.
+func Skip(err error) error {
+	if err == nil {
+		err = ErrSkip
+	}
+
+	return &HandlerError{Kind: ErrorKindSkip, Err: err}
+}
+
+// KindOf returns the kind of the given error. The errors that are not
+// classified by Permanent, Retryable or Skip are classified as follows:
+// DataError and ErrNoRoute are permanent, context.DeadlineExceeded is
+// retryable, ErrSkip is skip and any other error is unknown.
+func KindOf(err error) ErrorKind {
+	var (
+		herr *HandlerError
+		derr *DataError
+	)
+
+	switch {
+	case err == nil:
+		return ErrorKindUnknown
+	case errors.As(err, &herr):
+		return herr.Kind
+	case errors.Is(err, ErrSkip):
+		return ErrorKindSkip
+	case errors.As(err, &derr), errors.Is(err, ErrNoRoute):
+		return ErrorKindPermanent
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorKindRetryable
+	default:
+		return ErrorKindUnknown
+	}
+}
+
+// RetryAfterOf returns the suggested delay of a retryable error.
+func RetryAfterOf(err error) (time.Duration, bool) {
+	var herr *HandlerError
+
+	if errors.As(err, &herr) && herr.Kind == ErrorKindRetryable && herr.RetryAfter > 0 {
+		return herr.RetryAfter, true
+	}
+
+	return 0, false
+}
+
+//counterfeiter:generate -o ./eventv1fake . ErrorHandler
+
+// ErrorHandler is the interface that wraps the HandleError method.
+type ErrorHandler interface {
+	// HandleError handles the event that failed permanently with the given error.
+	HandleError(context.Context, *Event, error) error
+}
@@ -0,0 +1,70 @@
+package eventv1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestKindOf(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		kind eventv1.ErrorKind
+	}{
+		{"nil", nil, eventv1.ErrorKindUnknown},
+		{"plain", errors.New("a"), eventv1.ErrorKindUnknown},
+		{"permanent", eventv1.Permanent(errors.New("a")), eventv1.ErrorKindPermanent},
+		{"wrapped permanent", fmt.Errorf("b: %w", eventv1.Permanent(errors.New("a"))), eventv1.ErrorKindPermanent},
+		{"retryable", eventv1.Retryable(errors.New("a"), time.Second), eventv1.ErrorKindRetryable},
+		{"skip", eventv1.Skip(nil), eventv1.ErrorKindSkip},
+		{"skip sentinel", eventv1.ErrSkip, eventv1.ErrorKindSkip},
+		{"data error", &eventv1.DataError{Err: errors.New("a")}, eventv1.ErrorKindPermanent},
+		{"no route", fmt.Errorf("a: %w", eventv1.ErrNoRoute), eventv1.ErrorKindPermanent},
+		{"deadline", context.DeadlineExceeded, eventv1.ErrorKindRetryable},
+		{"classified deadline", eventv1.Permanent(context.DeadlineExceeded), eventv1.ErrorKindPermanent},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			if got := eventv1.KindOf(item.err); got != item.kind {
+				t.Errorf("got %v, want %v", got, item.kind)
+			}
+		})
+	}
+}
+
+func TestRetryAfterOf(t *testing.T) {
+	if after, ok := eventv1.RetryAfterOf(eventv1.Retryable(errors.New("a"), time.Second)); !ok || after != time.Second {
+		t.Errorf("got %v, %v, want 1s", after, ok)
+	}
+
+	if _, ok := eventv1.RetryAfterOf(eventv1.Retryable(errors.New("a"), 0)); ok {
+		t.Error("unexpected delay")
+	}
+
+	if _, ok := eventv1.RetryAfterOf(eventv1.Permanent(errors.New("a"))); ok {
+		t.Error("unexpected delay of a permanent error")
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	cause := errors.New("cause")
+
+	err := eventv1.Permanent(cause)
+	if !errors.Is(err, cause) {
+		t.Error("the error does not unwrap the cause")
+	}
+
+	if got := err.Error(); got != "permanent: cause" {
+		t.Errorf("got %q", got)
+	}
+
+	if got := eventv1.Skip(nil).Error(); got != "skip" {
+		t.Errorf("got %q, want skip", got)
+	}
+}
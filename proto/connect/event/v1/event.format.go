@@ -0,0 +1,463 @@
+package eventv1
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// ContentTypeCloudEventsJSON is the media type of the CloudEvents JSON event format.
+	ContentTypeCloudEventsJSON = "application/cloudevents+json"
+	// ContentTypeCloudEventsBatchJSON is the media type of the CloudEvents JSON batch format.
+	ContentTypeCloudEventsBatchJSON = "application/cloudevents-batch+json"
+	// ContentTypeCloudEventsProtobuf is the content type of the events that carry proto data.
+	ContentTypeCloudEventsProtobuf = "application/cloudevents+protobuf"
+)
+
+// AttributeKindsMember is the member of the CloudEvents JSON event format that
+// records the type of the extensions that JSON cannot express, such as Binary,
+// URI, URI-reference and Timestamp. It has the form of the AttributeKindsKey
+// attribute. It is written and read only when CloudEventJSONOptions enables
+// AttributeKinds, in which case the member is a reserved name.
+const AttributeKindsMember = "attributekinds"
+
+// CloudEventJSONOptions represents the options of the CloudEvents JSON event
+// and batch formats. The zero value encodes and decodes the events as defined
+// by the CloudEvents specification.
+type CloudEventJSONOptions struct {
+	// AttributeKinds records the type of the extensions that are neither
+	// Strings, Booleans, Integers nor registered by RegisterAttributeKind in
+	// the AttributeKindsMember member, and restores the extensions with it.
+	// The member is not defined by the CloudEvents specification, so other
+	// SDKs read it as a String extension. Enable it only when both ends use
+	// this package, otherwise register the extensions with RegisterAttributeKind.
+	AttributeKinds bool
+}
+
+// MarshalCloudEventJSON encodes the event in the CloudEvents JSON event
+// format with the default CloudEventJSONOptions. The context attributes and
+// the extensions are written as top-level members. Boolean and Integer
+// attributes are written as JSON booleans and numbers, all other attributes
+// are written in their canonical string form, so their type is restored only
+// when it is registered by RegisterAttributeKind.
+//
+// TextData is written as the data member, in its JSON form when the data
+// content type is JSON and the text is a JSON value other than a string.
+// BinaryData is written as the data member when the data content type is JSON
+// and the data is a JSON value other than a string, otherwise it is written as
+// the data_base64 member. ProtoData is written as the data member in the
+// protojson encoding of google.protobuf.Any, or as the data_base64 member that
+// contains the binary encoding of google.protobuf.Any when the message type is
+// not linked into the binary.
+func (x *Event) MarshalCloudEventJSON() ([]byte, error) {
+	return CloudEventJSONOptions{}.Marshal(x)
+}
+
+// Marshal encodes the event in the CloudEvents JSON event format as described
+// by Event.MarshalCloudEventJSON. The type of the extensions is recorded in
+// the AttributeKindsMember member when AttributeKinds is enabled.
+func (o CloudEventJSONOptions) Marshal(x *Event) ([]byte, error) {
+	members := make(map[string]any)
+	members["specversion"] = x.GetSpecVersion()
+	members["id"] = x.GetId()
+	members["source"] = x.GetSource()
+	members["type"] = x.GetType()
+
+	for name, attribute := range x.GetAttributes() {
+		if o.isReserved(name) {
+			return nil, fmt.Errorf("cannot marshal the attribute %q: reserved name", name)
+		}
+		// prepare the value
+		switch attr := attribute.GetAttr().(type) {
+		case nil:
+			continue
+		case *EventAttributeValue_CeBoolean:
+			members[name] = attr.CeBoolean
+		case *EventAttributeValue_CeInteger:
+			members[name] = attr.CeInteger
+		default:
+			members[name] = attribute.Format()
+		}
+	}
+
+	if o.AttributeKinds {
+		// set the hint
+		if kinds := formatAttributeKinds(x.GetAttributes(), AttributeKindBoolean, AttributeKindInteger); kinds != "" {
+			members[AttributeKindsMember] = kinds
+		}
+	}
+
+	ctype := x.GetDataContentType()
+	// prepare the data
+	switch payload := x.GetData().(type) {
+	case *Event_TextData:
+		if isJSONContentType(ctype) && json.Valid([]byte(payload.TextData)) && !isJSONString([]byte(payload.TextData)) {
+			members["data"] = json.RawMessage(payload.TextData)
+		} else {
+			members["data"] = payload.TextData
+		}
+	case *Event_BinaryData:
+		if isJSONContentType(ctype) && json.Valid(payload.BinaryData) && !isJSONString(payload.BinaryData) {
+			members["data"] = json.RawMessage(payload.BinaryData)
+		} else {
+			members["data_base64"] = base64.StdEncoding.EncodeToString(payload.BinaryData)
+		}
+	case *Event_ProtoData:
+		if data, err := protojson.Marshal(payload.ProtoData); err == nil {
+			members["data"] = json.RawMessage(data)
+		} else {
+			data, err := proto.Marshal(payload.ProtoData)
+			if err != nil {
+				return nil, err
+			}
+
+			members["data_base64"] = base64.StdEncoding.EncodeToString(data)
+		}
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalCloudEventJSON decodes the event from the CloudEvents JSON event
+// format with the default CloudEventJSONOptions. It reverses
+// MarshalCloudEventJSON. String members that are not context attributes are
+// decoded with the type registered for them by RegisterAttributeKind, or as
+// String attributes otherwise.
+//
+// A data member that holds a JSON string is decoded as TextData, any other JSON
+// value is decoded as BinaryData. So TextData that holds a JSON object, array,
+// number or boolean is restored as BinaryData.
+func (x *Event) UnmarshalCloudEventJSON(data []byte) error {
+	return CloudEventJSONOptions{}.Unmarshal(data, x)
+}
+
+// Unmarshal decodes the event from the CloudEvents JSON event format as
+// described by Event.UnmarshalCloudEventJSON. When AttributeKinds is enabled
+// the extensions are decoded with the type recorded in the
+// AttributeKindsMember member first.
+func (o CloudEventJSONOptions) Unmarshal(data []byte, x *Event) error {
+	members := make(map[string]json.RawMessage)
+	// unmarshal the members
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	if _, ok := members["specversion"]; !ok {
+		return fmt.Errorf("cannot unmarshal the event: missing specversion")
+	}
+
+	kinds := make(map[string]AttributeKind)
+	// prepare the kinds
+	if value, ok := members[AttributeKindsMember]; ok && o.AttributeKinds {
+		hint, err := unmarshalJSONString(AttributeKindsMember, value)
+		if err != nil {
+			return err
+		}
+
+		if kinds, err = parseAttributeKinds(hint); err != nil {
+			return err
+		}
+	}
+
+	proto.Reset(x)
+	x.Attributes = make(map[string]*EventAttributeValue)
+
+	for name, value := range members {
+		var err error
+
+		if name == AttributeKindsMember && o.AttributeKinds {
+			continue
+		}
+
+		switch name {
+		case "data", "data_base64":
+			continue
+		case "specversion":
+			x.SpecVersion, err = unmarshalJSONString(name, value)
+		case "id":
+			x.Id, err = unmarshalJSONString(name, value)
+		case "source":
+			x.Source, err = unmarshalJSONString(name, value)
+		case "type":
+			x.Type, err = unmarshalJSONString(name, value)
+		default:
+			var attribute *EventAttributeValue
+			// unmarshal the attribute
+			attribute, err = unmarshalJSONAttribute(name, value, kinds)
+			if attribute != nil {
+				x.Attributes[name] = attribute
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return x.unmarshalJSONData(members["data"], members["data_base64"])
+}
+
+// EventBatchEntryError is returned when an entry of a batch cannot be encoded
+// or decoded.
+type EventBatchEntryError struct {
+	// Index is the position of the entry in the batch.
+	Index int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error.
+func (e *EventBatchEntryError) Error() string {
+	return fmt.Sprintf("events[%d]: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *EventBatchEntryError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalCloudEventJSON encodes the batch in the CloudEvents JSON batch
+// format with the default CloudEventJSONOptions. Each event is encoded with
+// Event.MarshalCloudEventJSON. The returned error joins an EventBatchEntryError
+// for every event that cannot be encoded.
+func (x *EventBatch) MarshalCloudEventJSON() ([]byte, error) {
+	return CloudEventJSONOptions{}.MarshalBatch(x)
+}
+
+// MarshalBatch encodes the batch in the CloudEvents JSON batch format as
+// described by EventBatch.MarshalCloudEventJSON.
+func (o CloudEventJSONOptions) MarshalBatch(x *EventBatch) ([]byte, error) {
+	var errs []error
+
+	entries := make([]json.RawMessage, 0, len(x.GetEvents()))
+	// marshal the events
+	for index, event := range x.GetEvents() {
+		data, err := o.Marshal(event)
+		if err != nil {
+			errs = append(errs, &EventBatchEntryError{Index: index, Err: err})
+			continue
+		}
+
+		entries = append(entries, data)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(entries)
+}
+
+// UnmarshalCloudEventJSON decodes the batch from the CloudEvents JSON batch
+// format with the default CloudEventJSONOptions. Each event is decoded with
+// Event.UnmarshalCloudEventJSON. The returned error joins an
+// EventBatchEntryError for every malformed entry, while the batch keeps the
+// events that were decoded successfully.
+func (x *EventBatch) UnmarshalCloudEventJSON(data []byte) error {
+	return CloudEventJSONOptions{}.UnmarshalBatch(data, x)
+}
+
+// UnmarshalBatch decodes the batch from the CloudEvents JSON batch format as
+// described by EventBatch.UnmarshalCloudEventJSON.
+func (o CloudEventJSONOptions) UnmarshalBatch(data []byte, x *EventBatch) error {
+	var entries []json.RawMessage
+	// unmarshal the entries
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	var errs []error
+
+	x.Events = make([]*Event, 0, len(entries))
+	// unmarshal the events
+	for index, entry := range entries {
+		event := &Event{}
+		// unmarshal the event
+		if err := o.Unmarshal(entry, event); err != nil {
+			errs = append(errs, &EventBatchEntryError{Index: index, Err: err})
+			continue
+		}
+
+		x.Events = append(x.Events, event)
+	}
+
+	return errors.Join(errs...)
+}
+
+// isReserved reports whether the given name cannot be used as an extension.
+func (o CloudEventJSONOptions) isReserved(name string) bool {
+	switch name {
+	case "specversion", "id", "source", "type", "data", "data_base64":
+		return true
+	case AttributeKindsMember:
+		return o.AttributeKinds
+	default:
+		return false
+	}
+}
+
+func (x *Event) unmarshalJSONData(data, data64 json.RawMessage) error {
+	if isJSONNull(data) && isJSONNull(data64) {
+		return nil
+	}
+
+	if !isJSONNull(data) && !isJSONNull(data64) {
+		return fmt.Errorf("cannot unmarshal the event: both data and data_base64 are present")
+	}
+
+	ctype := x.GetDataContentType()
+
+	if !isJSONNull(data64) {
+		value, err := unmarshalJSONString("data_base64", data64)
+		if err != nil {
+			return err
+		}
+
+		payload, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("cannot unmarshal the data_base64: %w", err)
+		}
+
+		if strings.EqualFold(ctype, ContentTypeCloudEventsProtobuf) {
+			entity := &anypb.Any{}
+			// unmarshal the entity
+			if err := proto.Unmarshal(payload, entity); err != nil {
+				return fmt.Errorf("cannot unmarshal the data_base64: %w", err)
+			}
+			// set the data
+			x.Data = &Event_ProtoData{
+				ProtoData: entity,
+			}
+
+			return nil
+		}
+		// set the data
+		x.Data = &Event_BinaryData{
+			BinaryData: payload,
+		}
+
+		return nil
+	}
+
+	switch {
+	case strings.EqualFold(ctype, ContentTypeCloudEventsProtobuf):
+		entity := &anypb.Any{}
+		// unmarshal the entity
+		if err := protojson.Unmarshal(data, entity); err != nil {
+			return fmt.Errorf("cannot unmarshal the data: %w", err)
+		}
+		// set the data
+		x.Data = &Event_ProtoData{
+			ProtoData: entity,
+		}
+	case isJSONString(data):
+		var text string
+		// unmarshal the text
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("cannot unmarshal the data: %w", err)
+		}
+		// set the data
+		x.Data = &Event_TextData{
+			TextData: text,
+		}
+	default:
+		// the data is a json value
+		x.Data = &Event_BinaryData{
+			BinaryData: bytes.Clone(data),
+		}
+	}
+
+	return nil
+}
+
+func unmarshalJSONString(name string, data json.RawMessage) (string, error) {
+	var value string
+	// unmarshal the value
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", fmt.Errorf("cannot unmarshal the attribute %q: %w", name, err)
+	}
+
+	return value, nil
+}
+
+func unmarshalJSONAttribute(name string, data json.RawMessage, kinds map[string]AttributeKind) (*EventAttributeValue, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	// unmarshal the value
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal the attribute %q: %w", name, err)
+	}
+
+	var (
+		attribute *EventAttributeValue
+		err       error
+	)
+
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		attribute = &EventAttributeValue{
+			Attr: &EventAttributeValue_CeBoolean{
+				CeBoolean: v,
+			},
+		}
+	case json.Number:
+		attribute, err = ParseAttributeValue(AttributeKindInteger, v.String())
+	case string:
+		kind, ok := kinds[name]
+		if !ok {
+			kind, ok = LookupAttributeKind(name)
+		}
+
+		if !ok {
+			kind = AttributeKindString
+		}
+
+		attribute, err = ParseAttributeValue(kind, v)
+	default:
+		err = fmt.Errorf("unsupported value %s", data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal the attribute %q: %w", name, err)
+	}
+
+	return attribute, nil
+}
+
+func isJSONNull(data json.RawMessage) bool {
+	return len(data) == 0 || bytes.Equal(data, []byte("null"))
+}
+
+// isJSONString reports whether the given JSON value is a string.
+func isJSONString(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '"'
+}
+
+// isJSONContentType reports whether the given content type describes a JSON
+// value. An empty content type implies application/json.
+func isJSONContentType(ctype string) bool {
+	if ctype == "" {
+		return true
+	}
+
+	mtype, _, err := mime.ParseMediaType(ctype)
+	if err != nil {
+		return false
+	}
+
+	return mtype == "application/json" || mtype == "text/json" || strings.HasSuffix(mtype, "+json")
+}
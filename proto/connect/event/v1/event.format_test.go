@@ -0,0 +1,325 @@
+package eventv1_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func newTestEvent(t *testing.T) *eventv1.Event {
+	t.Helper()
+
+	event := eventv1.NewEvent()
+	event.SetType("com.example.order.created")
+	event.SetSource("/orders")
+	event.SetSubject("order-1")
+	event.SetTime(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC))
+
+	return event
+}
+
+func TestEventCloudEventJSONAttributeKinds(t *testing.T) {
+	event := newTestEvent(t)
+
+	extensions := map[string]*eventv1.EventAttributeValue{
+		"extbool":   {Attr: &eventv1.EventAttributeValue_CeBoolean{CeBoolean: true}},
+		"extint":    {Attr: &eventv1.EventAttributeValue_CeInteger{CeInteger: -42}},
+		"extstr":    {Attr: &eventv1.EventAttributeValue_CeString{CeString: "2024-01-02T03:04:05Z"}},
+		"extbin":    {Attr: &eventv1.EventAttributeValue_CeBytes{CeBytes: []byte{0, 1, 2, 255}}},
+		"exturi":    {Attr: &eventv1.EventAttributeValue_CeUri{CeUri: "https://example.com/a?b=c"}},
+		"exturiref": {Attr: &eventv1.EventAttributeValue_CeUriRef{CeUriRef: "/a/b"}},
+		"extts":     {Attr: &eventv1.EventAttributeValue_CeTimestamp{CeTimestamp: timestamppb.New(time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC))}},
+	}
+
+	for name, value := range extensions {
+		event.Attributes[name] = value
+	}
+
+	if err := event.SetData("hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	options := eventv1.CloudEventJSONOptions{AttributeKinds: true}
+
+	data, err := options.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &eventv1.Event{}
+	if err := options.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded) {
+		t.Errorf("got %v, want %v", decoded, event)
+	}
+
+	for name, value := range extensions {
+		if got := decoded.GetAttributes()[name].GetKind(); got != value.GetKind() {
+			t.Errorf("attribute %v has kind %v, want %v", name, got, value.GetKind())
+		}
+	}
+
+	if _, ok := decoded.GetAttributes()[eventv1.AttributeKindsMember]; ok {
+		t.Errorf("the hint %v is an attribute", eventv1.AttributeKindsMember)
+	}
+}
+
+func TestEventCloudEventJSONWithoutAttributeKinds(t *testing.T) {
+	event := newTestEvent(t)
+	event.Attributes["extbin"] = &eventv1.EventAttributeValue{Attr: &eventv1.EventAttributeValue_CeBytes{CeBytes: []byte{0, 1}}}
+	event.Attributes[eventv1.AttributeKindsMember] = &eventv1.EventAttributeValue{Attr: &eventv1.EventAttributeValue_CeString{CeString: "a"}}
+
+	data, err := event.MarshalCloudEventJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members := make(map[string]any)
+	if err := json.Unmarshal(data, &members); err != nil {
+		t.Fatal(err)
+	}
+
+	// the extension is written as it is and no hint is added
+	if got := members[eventv1.AttributeKindsMember]; got != "a" {
+		t.Errorf("got member %v, want the extension value", got)
+	}
+
+	decoded := &eventv1.Event{}
+	if err := decoded.UnmarshalCloudEventJSON(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := decoded.GetAttributes()[eventv1.AttributeKindsMember].GetCeString(); got != "a" {
+		t.Errorf("got extension %q, want a", got)
+	}
+
+	if got := decoded.GetAttributes()["extbin"].GetKind(); got != eventv1.AttributeKindString {
+		t.Errorf("got kind %v, want %v", got, eventv1.AttributeKindString)
+	}
+}
+
+func TestEventCloudEventJSONRegisteredKind(t *testing.T) {
+	registerTestAttributeKind(t, "testregisteredts", eventv1.AttributeKindTimestamp)
+
+	data := []byte(`{"specversion":"1.0","id":"1","source":"/s","type":"t","testregisteredts":"2024-01-02T03:04:05Z"}`)
+
+	event := &eventv1.Event{}
+	if err := event.UnmarshalCloudEventJSON(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := event.GetAttributes()["testregisteredts"].GetKind(); got != eventv1.AttributeKindTimestamp {
+		t.Errorf("got kind %v, want %v", got, eventv1.AttributeKindTimestamp)
+	}
+
+	encoded, err := eventv1.CloudEventJSONOptions{AttributeKinds: true}.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(encoded), eventv1.AttributeKindsMember) {
+		t.Errorf("registered kinds are recorded in the hint: %s", encoded)
+	}
+}
+
+func TestEventCloudEventJSONData(t *testing.T) {
+	entity, err := anypb.New(timestamppb.New(time.Unix(10, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		ctype  string
+		data   any
+		want   any
+		member string
+	}{
+		{"text without content type", "", &eventv1.Event_TextData{TextData: "hello"}, nil, "data"},
+		{"text plain", "text/plain", &eventv1.Event_TextData{TextData: "hello"}, nil, "data"},
+		{"text json string", "application/json", &eventv1.Event_TextData{TextData: `"hello"`}, nil, "data"},
+		{"text json object", "application/json", &eventv1.Event_TextData{TextData: `{"a":1}`}, &eventv1.Event_BinaryData{BinaryData: []byte(`{"a":1}`)}, "data"},
+		{"binary octet stream", "application/octet-stream", &eventv1.Event_BinaryData{BinaryData: []byte{0, 1, 2}}, nil, "data_base64"},
+		{"binary without content type", "", &eventv1.Event_BinaryData{BinaryData: []byte{0xff}}, nil, "data_base64"},
+		{"binary json object", "application/json", &eventv1.Event_BinaryData{BinaryData: []byte(`{"a":[1,2]}`)}, nil, "data"},
+		{"binary json string", "application/json", &eventv1.Event_BinaryData{BinaryData: []byte(`"a"`)}, nil, "data_base64"},
+		{"proto", eventv1.ContentTypeCloudEventsProtobuf, &eventv1.Event_ProtoData{ProtoData: entity}, nil, "data"},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			event := newTestEvent(t)
+			if item.ctype != "" {
+				event.SetDataContentType(item.ctype)
+			}
+
+			switch data := item.data.(type) {
+			case *eventv1.Event_TextData:
+				event.Data = data
+			case *eventv1.Event_BinaryData:
+				event.Data = data
+			case *eventv1.Event_ProtoData:
+				event.Data = data
+			}
+
+			encoded, err := event.MarshalCloudEventJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			members := make(map[string]json.RawMessage)
+			if err := json.Unmarshal(encoded, &members); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, ok := members[item.member]; !ok {
+				t.Errorf("missing member %v in %s", item.member, encoded)
+			}
+
+			decoded := &eventv1.Event{}
+			if err := decoded.UnmarshalCloudEventJSON(encoded); err != nil {
+				t.Fatal(err)
+			}
+
+			want := proto.Clone(event).(*eventv1.Event)
+
+			switch data := item.want.(type) {
+			case *eventv1.Event_BinaryData:
+				want.Data = data
+			}
+
+			if !proto.Equal(want, decoded) {
+				t.Errorf("got %v, want %v", decoded, want)
+			}
+		})
+	}
+}
+
+func TestEventCloudEventJSONErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+	}{
+		{"missing specversion", `{"id":"1","source":"/s","type":"t"}`},
+		{"both data members", `{"specversion":"1.0","id":"1","source":"/s","type":"t","data":"a","data_base64":"YQ=="}`},
+		{"invalid base64", `{"specversion":"1.0","id":"1","source":"/s","type":"t","data_base64":"%"}`},
+		{"invalid hint", `{"specversion":"1.0","id":"1","source":"/s","type":"t","attributekinds":"a:Float"}`},
+		{"invalid hinted value", `{"specversion":"1.0","id":"1","source":"/s","type":"t","attributekinds":"a:Timestamp","a":"now"}`},
+		{"invalid attribute", `{"specversion":"1.0","id":"1","source":"/s","type":"t","a":{}}`},
+	}
+
+	options := eventv1.CloudEventJSONOptions{AttributeKinds: true}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			if err := options.Unmarshal([]byte(item.data), &eventv1.Event{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	t.Run("reserved name", func(t *testing.T) {
+		event := newTestEvent(t)
+		event.Attributes[eventv1.AttributeKindsMember] = &eventv1.EventAttributeValue{
+			Attr: &eventv1.EventAttributeValue_CeString{CeString: "a"},
+		}
+
+		if _, err := options.Marshal(event); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestEventBatchCloudEventJSON(t *testing.T) {
+	batch := &eventv1.EventBatch{}
+
+	for _, text := range []string{"a", "b", "c"} {
+		event := newTestEvent(t)
+		if err := event.SetData(text); err != nil {
+			t.Fatal(err)
+		}
+
+		batch.Events = append(batch.Events, event)
+	}
+
+	data, err := batch.MarshalCloudEventJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &eventv1.EventBatch{}
+	if err := decoded.UnmarshalCloudEventJSON(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(batch, decoded) {
+		t.Errorf("got %v, want %v", decoded, batch)
+	}
+}
+
+func TestEventBatchCloudEventJSONEmpty(t *testing.T) {
+	data, err := (&eventv1.EventBatch{}).MarshalCloudEventJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "[]" {
+		t.Errorf("got %s, want []", data)
+	}
+}
+
+func TestEventBatchCloudEventJSONEntryError(t *testing.T) {
+	data := `[
+		{"specversion":"1.0","id":"1","source":"/s","type":"t"},
+		{"id":"2","source":"/s","type":"t"},
+		{"specversion":"1.0","id":"3","source":"/s","type":"t"}
+	]`
+
+	batch := &eventv1.EventBatch{}
+
+	err := batch.UnmarshalCloudEventJSON([]byte(data))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var entry *eventv1.EventBatchEntryError
+	if !errors.As(err, &entry) || entry.Index != 1 {
+		t.Errorf("got %v, want an error of entry 1", err)
+	}
+
+	if got := len(batch.GetEvents()); got != 2 {
+		t.Errorf("got %d events, want 2", got)
+	}
+
+	if err := batch.UnmarshalCloudEventJSON([]byte(`{}`)); err == nil {
+		t.Error("expected an error for a non-array batch")
+	}
+}
+
+func TestEventBatchCloudEventJSONMarshalEntryError(t *testing.T) {
+	event := newTestEvent(t)
+	event.Attributes["data"] = &eventv1.EventAttributeValue{
+		Attr: &eventv1.EventAttributeValue_CeString{CeString: "a"},
+	}
+
+	batch := &eventv1.EventBatch{
+		Events: []*eventv1.Event{newTestEvent(t), event},
+	}
+
+	_, err := batch.MarshalCloudEventJSON()
+
+	var entry *eventv1.EventBatchEntryError
+	if !errors.As(err, &entry) || entry.Index != 1 {
+		t.Errorf("got %v, want an error of entry 1", err)
+	}
+}
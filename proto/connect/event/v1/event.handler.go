@@ -0,0 +1,80 @@
+package eventv1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// DataError is returned when the event data cannot be decoded.
+type DataError struct {
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error.
+func (e *DataError) Error() string {
+	return fmt.Sprintf("cannot decode the event data: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DataError) Unwrap() error {
+	return e.Err
+}
+
+var _ EventHandler = TypedHandler[proto.Message](nil)
+
+// TypedHandler is an EventHandler that decodes the event data into a new
+// instance of the proto message T before it calls the function. ProtoData is
+// unmarshalled from google.protobuf.Any. TextData and BinaryData are decoded
+// with the DataCodec of the data content type, or from the protobuf binary
+// format when the content type has no codec. A data schema in the type URL
+// form must name the message T. Decode failures are returned as *DataError.
+type TypedHandler[T proto.Message] func(context.Context, *Event, T) error
+
+// HandleEvent implements EventHandler.
+func (fn TypedHandler[T]) HandleEvent(ctx context.Context, event *Event) error {
+	var zero T
+	// create a new entity
+	message := zero.ProtoReflect().New().Interface().(T)
+
+	if err := decodeData(event, message); err != nil {
+		return &DataError{Err: err}
+	}
+
+	return fn(ctx, event, message)
+}
+
+func decodeData(event *Event, message proto.Message) error {
+	name := string(message.ProtoReflect().Descriptor().FullName())
+
+	if schema := event.GetDataSchema(); strings.HasPrefix(schema, "type.googleapis.com/") {
+		if schema[strings.LastIndex(schema, "/")+1:] != name {
+			return fmt.Errorf("data schema %v does not match message %v", schema, name)
+		}
+	}
+
+	ctype := event.GetDataContentType()
+
+	var data []byte
+
+	switch payload := event.GetData().(type) {
+	case *Event_ProtoData:
+		return payload.ProtoData.UnmarshalTo(message)
+	case *Event_TextData:
+		data = []byte(payload.TextData)
+	case *Event_BinaryData:
+		data = payload.BinaryData
+	default:
+		return fmt.Errorf("no data")
+	}
+
+	codec, ok := LookupDataCodec(ctype)
+	if !ok {
+		codec = ProtoDataCodec
+	}
+
+	return codec.Unmarshal(data, message)
+}
@@ -0,0 +1,103 @@
+package eventv1_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestTypedHandler(t *testing.T) {
+	want := timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	raw, err := proto.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		modify func(*eventv1.Event) error
+	}{
+		{"proto data", func(event *eventv1.Event) error { return event.SetData(want) }},
+		{"json data", func(event *eventv1.Event) error { return event.SetDataWithContentType("application/json", want) }},
+		{"protobuf data", func(event *eventv1.Event) error { return event.SetDataWithContentType("application/protobuf", want) }},
+		{"binary data without codec", func(event *eventv1.Event) error { return event.SetData(raw) }},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			event := newTestEvent(t)
+			if err := item.modify(event); err != nil {
+				t.Fatal(err)
+			}
+
+			var got *timestamppb.Timestamp
+
+			handler := eventv1.TypedHandler[*timestamppb.Timestamp](func(_ context.Context, _ *eventv1.Event, message *timestamppb.Timestamp) error {
+				got = message
+				return nil
+			})
+
+			if err := handler.HandleEvent(context.Background(), event); err != nil {
+				t.Fatal(err)
+			}
+
+			if !proto.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTypedHandlerDataError(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*eventv1.Event) error
+	}{
+		{"no data", func(*eventv1.Event) error { return nil }},
+		{"schema mismatch", func(event *eventv1.Event) error { return event.SetData(durationpb.New(time.Second)) }},
+		{"invalid json", func(event *eventv1.Event) error {
+			event.SetDataContentType("application/json")
+			event.Data = &eventv1.Event_BinaryData{BinaryData: []byte("{")}
+			return nil
+		}},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			event := newTestEvent(t)
+			if err := item.modify(event); err != nil {
+				t.Fatal(err)
+			}
+
+			called := false
+
+			handler := eventv1.TypedHandler[*timestamppb.Timestamp](func(context.Context, *eventv1.Event, *timestamppb.Timestamp) error {
+				called = true
+				return nil
+			})
+
+			err := handler.HandleEvent(context.Background(), event)
+
+			var derr *eventv1.DataError
+			if !errors.As(err, &derr) {
+				t.Errorf("got %v, want a DataError", err)
+			}
+
+			if kind := eventv1.KindOf(err); kind != eventv1.ErrorKindPermanent {
+				t.Errorf("got kind %v, want %v", kind, eventv1.ErrorKindPermanent)
+			}
+
+			if called {
+				t.Error("the handler is called")
+			}
+		})
+	}
+}
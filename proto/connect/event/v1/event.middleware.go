@@ -0,0 +1,29 @@
+package eventv1
+
+import (
+	"context"
+)
+
+var _ EventHandler = EventHandlerFunc(nil)
+
+// EventHandlerFunc is an adapter to allow the use of ordinary functions as
+// event handlers.
+type EventHandlerFunc func(context.Context, *Event) error
+
+// HandleEvent implements EventHandler.
+func (fn EventHandlerFunc) HandleEvent(ctx context.Context, event *Event) error {
+	return fn(ctx, event)
+}
+
+// Middleware wraps an EventHandler with additional behavior.
+type Middleware func(EventHandler) EventHandler
+
+// Chain wraps the handler with the given middleware. The first middleware is
+// the outermost one, so Chain(h, a, b) handles an event with a(b(h)).
+func Chain(handler EventHandler, middleware ...Middleware) EventHandler {
+	for index := len(middleware) - 1; index >= 0; index-- {
+		handler = middleware[index](handler)
+	}
+
+	return handler
+}
@@ -0,0 +1,39 @@
+package eventv1_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+
+	middleware := func(name string) eventv1.Middleware {
+		return func(next eventv1.EventHandler) eventv1.EventHandler {
+			return eventv1.EventHandlerFunc(func(ctx context.Context, event *eventv1.Event) error {
+				calls = append(calls, name+">")
+				err := next.HandleEvent(ctx, event)
+				calls = append(calls, "<"+name)
+				return err
+			})
+		}
+	}
+
+	handler := eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+		calls = append(calls, "handler")
+		return nil
+	})
+
+	chain := eventv1.Chain(handler, middleware("a"), middleware("b"))
+
+	if err := chain.HandleEvent(context.Background(), newTestEvent(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(calls, " "); got != "a> b> handler <b <a" {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,201 @@
+package eventv1
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+)
+
+// ErrNoRoute is returned by EventMux when no route matches the event and no
+// fallback handler is registered.
+var ErrNoRoute = fmt.Errorf("no route")
+
+// EventRoute represents a route of EventMux. Every pattern matches the
+// corresponding event attribute. An empty pattern matches any value. A '*' in
+// a pattern matches any sequence of characters and a '?' matches any single
+// character, so "com.example.*" matches every type with the com.example.
+// prefix. A pattern without wildcards matches the exact value.
+type EventRoute struct {
+	// Type is the type attribute pattern.
+	Type string
+	// Source is the source attribute pattern.
+	Source string
+	// Subject is the subject attribute pattern.
+	Subject string
+	// DataContentType is the media type pattern of the datacontenttype
+	// attribute. The media type parameters are ignored.
+	DataContentType string
+	// Handler is the handler of the matching events.
+	Handler EventHandler
+}
+
+// String returns the route description.
+func (x EventRoute) String() string {
+	var items []string
+
+	for _, item := range []struct{ name, pattern string }{
+		{"type", x.Type},
+		{"source", x.Source},
+		{"subject", x.Subject},
+		{"datacontenttype", x.DataContentType},
+	} {
+		if item.pattern != "" {
+			items = append(items, item.name+"="+item.pattern)
+		}
+	}
+
+	if len(items) == 0 {
+		items = append(items, "*")
+	}
+
+	return fmt.Sprintf("%v -> %T", strings.Join(items, " "), x.Handler)
+}
+
+// Match reports whether the route matches the given event.
+func (x EventRoute) Match(event *Event) bool {
+	ctype := event.GetDataContentType()
+	// strip the parameters
+	if mtype, _, err := mime.ParseMediaType(ctype); err == nil {
+		ctype = mtype
+	}
+
+	return matchPattern(x.Type, event.GetType()) &&
+		matchPattern(x.Source, event.GetSource()) &&
+		matchPattern(x.Subject, event.GetSubject()) &&
+		matchPattern(strings.ToLower(x.DataContentType), strings.ToLower(ctype))
+}
+
+var _ EventHandler = &EventMux{}
+
+// EventMux is an EventHandler that dispatches the events to the handler of the
+// first registered route that matches the event. The events that match no
+// route are dispatched to the fallback handler, or rejected with ErrNoRoute.
+type EventMux struct {
+	mu       sync.RWMutex
+	routes   []EventRoute
+	fallback EventHandler
+}
+
+// NewEventMux returns a new instance of EventMux.
+func NewEventMux() *EventMux {
+	return &EventMux{}
+}
+
+// Handle registers the given route.
+func (x *EventMux) Handle(route EventRoute) {
+	if route.Handler == nil {
+		panic("eventv1: nil handler")
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.routes = append(x.routes, route)
+}
+
+// HandleType registers the handler of the events whose type matches the pattern.
+func (x *EventMux) HandleType(pattern string, handler EventHandler) {
+	x.Handle(EventRoute{Type: pattern, Handler: handler})
+}
+
+// HandleSource registers the handler of the events whose source matches the pattern.
+func (x *EventMux) HandleSource(pattern string, handler EventHandler) {
+	x.Handle(EventRoute{Source: pattern, Handler: handler})
+}
+
+// HandleSubject registers the handler of the events whose subject matches the pattern.
+func (x *EventMux) HandleSubject(pattern string, handler EventHandler) {
+	x.Handle(EventRoute{Subject: pattern, Handler: handler})
+}
+
+// HandleDataContentType registers the handler of the events whose data content
+// type matches the pattern.
+func (x *EventMux) HandleDataContentType(pattern string, handler EventHandler) {
+	x.Handle(EventRoute{DataContentType: pattern, Handler: handler})
+}
+
+// HandleFallback registers the handler of the events that match no route.
+func (x *EventMux) HandleFallback(handler EventHandler) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.fallback = handler
+}
+
+// Routes returns the registered routes in registration order. The fallback
+// handler is reported as the last route without patterns.
+func (x *EventMux) Routes() []EventRoute {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	routes := make([]EventRoute, len(x.routes), len(x.routes)+1)
+	copy(routes, x.routes)
+
+	if x.fallback != nil {
+		routes = append(routes, EventRoute{Handler: x.fallback})
+	}
+
+	return routes
+}
+
+// HandleEvent implements EventHandler.
+func (x *EventMux) HandleEvent(ctx context.Context, event *Event) error {
+	handler := x.lookup(event)
+	if handler == nil {
+		return fmt.Errorf("cannot handle the event %v of type %v: %w", event.GetId(), event.GetType(), ErrNoRoute)
+	}
+
+	return handler.HandleEvent(ctx, event)
+}
+
+func (x *EventMux) lookup(event *Event) EventHandler {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	for _, route := range x.routes {
+		if route.Match(event) {
+			return route.Handler
+		}
+	}
+
+	return x.fallback
+}
+
+// matchPattern reports whether the value matches the pattern.
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == value
+	}
+
+	// px and vx are the positions in the pattern and in the value, while
+	// (star, next) is the backtracking point of the last '*'.
+	px, vx, star, next := 0, 0, -1, 0
+
+	for vx < len(value) {
+		switch {
+		case px < len(pattern) && (pattern[px] == '?' || pattern[px] == value[vx]):
+			px++
+			vx++
+		case px < len(pattern) && pattern[px] == '*':
+			star, next = px, vx
+			px++
+		case star >= 0:
+			next++
+			px, vx = star+1, next
+		default:
+			return false
+		}
+	}
+
+	for px < len(pattern) && pattern[px] == '*' {
+		px++
+	}
+
+	return px == len(pattern)
+}
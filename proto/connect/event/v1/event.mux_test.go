@@ -0,0 +1,160 @@
+package eventv1_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestEventRouteMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		value   string
+		match   bool
+	}{
+		{"", "anything", true},
+		{"com.example.order", "com.example.order", true},
+		{"com.example.order", "com.example.orders", false},
+		{"com.example.*", "com.example.order.created", true},
+		{"com.example.*", "com.example.", true},
+		{"com.example.*", "com.other.order", false},
+		{"*.created", "com.example.order.created", true},
+		{"*.created", "com.example.order.deleted", false},
+		{"com.*.order.*", "com.example.order.created", true},
+		{"com.*.order.*", "com.example.invoice.created", false},
+		{"v?", "v1", true},
+		{"v?", "v10", false},
+		{"*a*b", "xaybzab", true},
+		{"*a*b", "xaybza", false},
+		{"*", "", true},
+		{"?", "", false},
+		{"**", "abc", true},
+	}
+
+	for _, item := range cases {
+		t.Run(item.pattern+" "+item.value, func(t *testing.T) {
+			event := newTestEvent(t)
+			event.SetType(item.value)
+
+			route := eventv1.EventRoute{Type: item.pattern}
+			if got := route.Match(event); got != item.match {
+				t.Errorf("got %v, want %v", got, item.match)
+			}
+		})
+	}
+}
+
+func TestEventRouteMatchAttributes(t *testing.T) {
+	event := newTestEvent(t)
+	event.SetDataContentType("Application/JSON; charset=utf-8")
+
+	cases := []struct {
+		name  string
+		route eventv1.EventRoute
+		match bool
+	}{
+		{"all", eventv1.EventRoute{Type: "com.example.*", Source: "/orders", Subject: "order-?", DataContentType: "application/json"}, true},
+		{"source", eventv1.EventRoute{Source: "/invoices"}, false},
+		{"subject", eventv1.EventRoute{Subject: "invoice-*"}, false},
+		{"data content type wildcard", eventv1.EventRoute{DataContentType: "application/*"}, true},
+		{"data content type", eventv1.EventRoute{DataContentType: "text/plain"}, false},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			if got := item.route.Match(event); got != item.match {
+				t.Errorf("got %v, want %v", got, item.match)
+			}
+		})
+	}
+}
+
+func TestEventMux(t *testing.T) {
+	var routed []string
+
+	handler := func(name string) eventv1.EventHandler {
+		return eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+			routed = append(routed, name)
+			return nil
+		})
+	}
+
+	mux := eventv1.NewEventMux()
+	mux.HandleType("com.example.order.created", handler("exact"))
+	mux.HandleType("com.example.*", handler("wildcard"))
+	mux.HandleSource("/invoices", handler("source"))
+	mux.HandleSubject("user-*", handler("subject"))
+	mux.HandleDataContentType("text/*", handler("ctype"))
+
+	events := []func(*eventv1.Event){
+		func(*eventv1.Event) {},
+		func(event *eventv1.Event) { event.SetType("com.example.order.deleted") },
+		func(event *eventv1.Event) { event.SetType("org.other"); event.SetSource("/invoices") },
+		func(event *eventv1.Event) { event.SetType("org.other"); event.SetSubject("user-1") },
+		func(event *eventv1.Event) { event.SetType("org.other"); _ = event.SetData("a") },
+	}
+
+	for _, modify := range events {
+		event := newTestEvent(t)
+		modify(event)
+
+		if err := mux.HandleEvent(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := strings.Join(routed, ","); got != "exact,wildcard,source,subject,ctype" {
+		t.Errorf("got routes %v", got)
+	}
+}
+
+func TestEventMuxFallback(t *testing.T) {
+	mux := eventv1.NewEventMux()
+	mux.HandleType("com.example.invoice.*", eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+		return nil
+	}))
+
+	event := newTestEvent(t)
+
+	err := mux.HandleEvent(context.Background(), event)
+	if !errors.Is(err, eventv1.ErrNoRoute) {
+		t.Errorf("got %v, want %v", err, eventv1.ErrNoRoute)
+	}
+
+	called := false
+
+	mux.HandleFallback(eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+		called = true
+		return nil
+	}))
+
+	if err := mux.HandleEvent(context.Background(), event); err != nil || !called {
+		t.Errorf("got %v, %v, want the fallback", err, called)
+	}
+
+	routes := mux.Routes()
+	if len(routes) != 2 {
+		t.Fatalf("got %d routes, want 2", len(routes))
+	}
+
+	if got := routes[1].String(); !strings.HasPrefix(got, "* -> ") {
+		t.Errorf("got fallback route %q", got)
+	}
+
+	if got := routes[0].String(); !strings.HasPrefix(got, "type=com.example.invoice.* -> ") {
+		t.Errorf("got route %q", got)
+	}
+}
+
+func TestEventMuxNilHandler(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+
+	eventv1.NewEventMux().HandleType("a", nil)
+}
@@ -2,23 +2,25 @@ package eventv1
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"math"
 	"net/url"
-	"reflect"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// ErrMissingExtension is returned by GetExtension when the event does not have the extension.
+var ErrMissingExtension = fmt.Errorf("no extension")
+
 //counterfeiter:generate -o ./eventv1fake . EventHandler
 
 // EventHandler is the interface that wraps the HandleEvent method.
@@ -37,7 +39,7 @@ func NewEvent() *Event {
 	event := &Event{
 		Id:          id.String(),
 		Attributes:  make(map[string]*EventAttributeValue),
-		SpecVersion: "1.0",
+		SpecVersion: SpecVersion,
 	}
 
 	return event
@@ -152,100 +154,370 @@ func (x *Event) SetDataContentType(value string) {
 	}
 }
 
-// SetExtension sets the Extension attribute.
-func (x *Event) SetExtension(name string, value interface{}) {
-	attributes := x.Attributes
+// SetExtension sets the Extension attribute. The value is stored with the
+// EventAttributeValue type that corresponds to its Go type:
+//
+//   - bool as CeBoolean
+//   - string as CeString
+//   - signed and unsigned integers as CeInteger, if they fit in an int32
+//   - []byte as CeBytes
+//   - *url.URL and url.URL as CeUri when absolute, as CeUriRef otherwise
+//   - time.Time and *timestamppb.Timestamp as CeTimestamp
+//   - *EventAttributeValue as is
+//
+// It returns an error for any other type.
+func (x *Event) SetExtension(name string, value interface{}) error {
+	attribute, err := newEventAttributeValue(value)
+	if err != nil {
+		return fmt.Errorf("cannot set the extension %q: %w", name, err)
+	}
 
-	switch v := value.(type) {
-	case bool:
-		value = &EventAttributeValue_CeBoolean{
-			CeBoolean: v,
+	if x.Attributes == nil {
+		x.Attributes = make(map[string]*EventAttributeValue)
+	}
+
+	x.Attributes[name] = attribute
+	return nil
+}
+
+// DeleteExtension deletes the Extension attribute.
+func (x *Event) DeleteExtension(name string) {
+	delete(x.Attributes, name)
+}
+
+// Extensions returns an iterator over the extension attributes in name order.
+// The optional context attributes subject, time, dataschema and
+// datacontenttype are not extensions.
+func (x *Event) Extensions() iter.Seq2[string, *EventAttributeValue] {
+	return func(yield func(string, *EventAttributeValue) bool) {
+		names := make([]string, 0, len(x.GetAttributes()))
+		// prepare the names
+		for name := range x.GetAttributes() {
+			switch name {
+			case "subject", "time", "dataschema", "datacontenttype":
+				continue
+			}
+
+			names = append(names, name)
 		}
-	case string:
-		value = &EventAttributeValue_CeString{
-			CeString: v,
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			if !yield(name, x.Attributes[name]) {
+				return
+			}
 		}
-	case int32:
-		value = &EventAttributeValue_CeInteger{
-			CeInteger: v,
+	}
+}
+
+// GetExtension returns the Extension attribute as a value of type T. The
+// supported types are bool, string, int32, int64, int, []byte, *url.URL,
+// time.Time, *timestamppb.Timestamp and *EventAttributeValue. A string is
+// returned for every attribute type in its canonical string form. It returns
+// ErrMissingExtension if the event does not have the extension.
+func GetExtension[T any](x *Event, name string) (T, error) {
+	var value T
+
+	attribute, ok := x.GetAttributes()[name]
+	if !ok {
+		return value, fmt.Errorf("cannot get the extension %q: %w", name, ErrMissingExtension)
+	}
+
+	mismatch := fmt.Errorf("cannot get the extension %q of type %v as %T", name, attribute.GetKind(), value)
+
+	switch v := any(&value).(type) {
+	case *string:
+		*v = attribute.Format()
+	case *bool:
+		attr, ok := attribute.GetAttr().(*EventAttributeValue_CeBoolean)
+		if !ok {
+			return value, mismatch
 		}
-	case []byte:
-		value = &EventAttributeValue_CeString{
-			CeString: base64.RawStdEncoding.EncodeToString(v),
+
+		*v = attr.CeBoolean
+	case *int32:
+		attr, ok := attribute.GetAttr().(*EventAttributeValue_CeInteger)
+		if !ok {
+			return value, mismatch
 		}
-	case *url.URL:
-		value = &EventAttributeValue_CeString{
-			CeString: v.String(),
+
+		*v = attr.CeInteger
+	case *int64:
+		attr, ok := attribute.GetAttr().(*EventAttributeValue_CeInteger)
+		if !ok {
+			return value, mismatch
 		}
-	case time.Time:
-		value = &EventAttributeValue_CeTimestamp{
-			CeTimestamp: timestamppb.New(v),
+
+		*v = int64(attr.CeInteger)
+	case *int:
+		attr, ok := attribute.GetAttr().(*EventAttributeValue_CeInteger)
+		if !ok {
+			return value, mismatch
 		}
-	case *timestamppb.Timestamp:
-		value = &EventAttributeValue_CeTimestamp{
-			CeTimestamp: v,
+
+		*v = int(attr.CeInteger)
+	case *[]byte:
+		attr, ok := attribute.GetAttr().(*EventAttributeValue_CeBytes)
+		if !ok {
+			return value, mismatch
 		}
-	}
 
-	switch attr := value.(type) {
-	case *EventAttributeValue_CeBoolean:
-		attributes[name] = &EventAttributeValue{
-			Attr: attr,
+		*v = attr.CeBytes
+	case **url.URL:
+		switch attribute.GetKind() {
+		case AttributeKindURI, AttributeKindURIRef:
+			uri, err := url.Parse(attribute.Format())
+			if err != nil {
+				return value, fmt.Errorf("cannot get the extension %q: %w", name, err)
+			}
+
+			*v = uri
+		default:
+			return value, mismatch
 		}
-	case *EventAttributeValue_CeBytes:
-		attributes[name] = &EventAttributeValue{
-			Attr: attr,
+	case *time.Time:
+		attr, ok := attribute.GetAttr().(*EventAttributeValue_CeTimestamp)
+		if !ok {
+			return value, mismatch
 		}
-	case *EventAttributeValue_CeInteger:
-		attributes[name] = &EventAttributeValue{
-			Attr: attr,
+
+		*v = attr.CeTimestamp.AsTime()
+	case **timestamppb.Timestamp:
+		attr, ok := attribute.GetAttr().(*EventAttributeValue_CeTimestamp)
+		if !ok {
+			return value, mismatch
 		}
-	case *EventAttributeValue_CeString:
-		attributes[name] = &EventAttributeValue{
-			Attr: attr,
+
+		*v = attr.CeTimestamp
+	case **EventAttributeValue:
+		*v = attribute
+	default:
+		return value, mismatch
+	}
+
+	return value, nil
+}
+
+// newEventAttributeValue returns the EventAttributeValue that corresponds to
+// the Go type of the given value.
+func newEventAttributeValue(value interface{}) (*EventAttributeValue, error) {
+	var integer int64
+
+	switch v := value.(type) {
+	case *EventAttributeValue:
+		if v.GetKind() == AttributeKindUnknown {
+			return nil, fmt.Errorf("unsupported empty value")
+		}
+
+		return v, nil
+	case bool:
+		return &EventAttributeValue{
+			Attr: &EventAttributeValue_CeBoolean{
+				CeBoolean: v,
+			},
+		}, nil
+	case string:
+		return &EventAttributeValue{
+			Attr: &EventAttributeValue_CeString{
+				CeString: v,
+			},
+		}, nil
+	case []byte:
+		return &EventAttributeValue{
+			Attr: &EventAttributeValue_CeBytes{
+				CeBytes: v,
+			},
+		}, nil
+	case url.URL:
+		return newEventAttributeValue(&v)
+	case *url.URL:
+		if v == nil {
+			return nil, fmt.Errorf("unsupported nil value")
+		}
+
+		if v.IsAbs() {
+			return &EventAttributeValue{
+				Attr: &EventAttributeValue_CeUri{
+					CeUri: v.String(),
+				},
+			}, nil
 		}
-	case *EventAttributeValue_CeTimestamp:
-		attributes[name] = &EventAttributeValue{
-			Attr: attr,
+
+		return &EventAttributeValue{
+			Attr: &EventAttributeValue_CeUriRef{
+				CeUriRef: v.String(),
+			},
+		}, nil
+	case time.Time:
+		return &EventAttributeValue{
+			Attr: &EventAttributeValue_CeTimestamp{
+				CeTimestamp: timestamppb.New(v),
+			},
+		}, nil
+	case *timestamppb.Timestamp:
+		if v == nil {
+			return nil, fmt.Errorf("unsupported nil value")
 		}
-	case *EventAttributeValue_CeUri:
-		attributes[name] = &EventAttributeValue{
-			Attr: attr,
+
+		return &EventAttributeValue{
+			Attr: &EventAttributeValue_CeTimestamp{
+				CeTimestamp: v,
+			},
+		}, nil
+	case int:
+		integer = int64(v)
+	case int8:
+		integer = int64(v)
+	case int16:
+		integer = int64(v)
+	case int32:
+		integer = int64(v)
+	case int64:
+		integer = v
+	case uint:
+		if uint64(v) > math.MaxInt32 {
+			return nil, fmt.Errorf("integer %d out of range", v)
 		}
-	case *EventAttributeValue_CeUriRef:
-		attributes[name] = &EventAttributeValue{
-			Attr: attr,
+
+		integer = int64(v)
+	case uint8:
+		integer = int64(v)
+	case uint16:
+		integer = int64(v)
+	case uint32:
+		integer = int64(v)
+	case uint64:
+		if v > math.MaxInt32 {
+			return nil, fmt.Errorf("integer %d out of range", v)
 		}
+
+		integer = int64(v)
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+
+	if integer < math.MinInt32 || integer > math.MaxInt32 {
+		return nil, fmt.Errorf("integer %d out of range", integer)
 	}
+
+	return &EventAttributeValue{
+		Attr: &EventAttributeValue_CeInteger{
+			CeInteger: int32(integer),
+		},
+	}, nil
 }
 
 // GetDataAs attempts to populate the provided data object with the event
-// payload. The object should be a pointer type.
+// payload. The object should be a pointer type. ProtoData is unmarshalled into
+// a proto.Message. TextData and BinaryData are decoded with the DataCodec of
+// the data content type, as returned by LookupDataCodec. A *[]byte object
+// receives the raw data regardless of the content type.
 func (x *Event) GetDataAs(value interface{}) error {
 	ctype := x.GetDataContentType()
 
-	switch {
-	case strings.HasPrefix(ctype, "text"):
-		data := x.GetTextData()
-		// unmarshal the data
-		value := reflect.ValueOf(value)
-		value.Elem().SetString(data)
-		// done!
-		return nil
-	case strings.EqualFold(ctype, "application/json"):
-		data := x.GetBinaryData()
-		// unmarshal the data
-		return json.Unmarshal(data, value)
-	case strings.EqualFold(ctype, "application/cloudevents+protobuf"):
+	var data []byte
+
+	switch payload := x.GetData().(type) {
+	case *Event_ProtoData:
 		if value, ok := value.(proto.Message); ok {
 			// unmarshal the data
-			return x.GetProtoData().UnmarshalTo(value)
+			return payload.ProtoData.UnmarshalTo(value)
 		}
+
+		return fmt.Errorf("cannot get the data with content-type %v as data-type %T", ctype, value)
+	case *Event_TextData:
+		data = []byte(payload.TextData)
+	case *Event_BinaryData:
+		data = payload.BinaryData
+	}
+
+	if value, ok := value.(*[]byte); ok {
+		*value = data
+		// done!
+		return nil
+	}
+
+	if codec, ok := LookupDataCodec(ctype); ok {
+		// unmarshal the data
+		return codec.Unmarshal(data, value)
 	}
 
 	return fmt.Errorf("cannot get the data with content-type %v as data-type %T", ctype, value)
 }
 
+// SetDataWithContentType encodes the given payload with the DataCodec of the
+// given content type, as returned by LookupDataCodec, and sets the data content
+// type. The text media types are stored as TextData, all other media types as
+// BinaryData. The application/cloudevents+protobuf content type stores the
+// proto.Message payload as ProtoData. A []byte payload is taken as data that is
+// already encoded in the given content type, so it is stored as it is instead
+// of being encoded by the DataCodec, which would turn it into a base64 JSON
+// string for application/json. If the payload is a proto.Message, its type URL
+// is used as the data schema.
+func (x *Event) SetDataWithContentType(ctype string, value interface{}) error {
+	if strings.EqualFold(ctype, ContentTypeCloudEventsProtobuf) {
+		data, ok := value.(proto.Message)
+		if !ok {
+			return fmt.Errorf("cannot set the data with content-type %v as data-type %T", ctype, value)
+		}
+
+		// SetData would encode a message that implements json.Marshaler as JSON
+		message, ok := data.(*anypb.Any)
+		if !ok {
+			var err error
+			// create a new entity
+			message, err = anypb.New(data)
+			if err != nil {
+				return err
+			}
+		}
+
+		x.SetDataSchema(message.TypeUrl)
+		x.SetDataContentType(ContentTypeCloudEventsProtobuf)
+		// set the data
+		x.Data = &Event_ProtoData{
+			ProtoData: message,
+		}
+
+		return nil
+	}
+
+	codec, ok := LookupDataCodec(ctype)
+	if !ok {
+		return fmt.Errorf("cannot set the data with content-type %v as data-type %T", ctype, value)
+	}
+
+	payload, ok := value.([]byte)
+	if !ok {
+		var err error
+		// marshal the payload
+		if payload, err = codec.Marshal(value); err != nil {
+			return err
+		}
+	}
+
+	if strings.HasPrefix(strings.ToLower(ctype), "text/") {
+		x.Data = &Event_TextData{
+			TextData: string(payload),
+		}
+	} else {
+		x.Data = &Event_BinaryData{
+			BinaryData: payload,
+		}
+	}
+
+	x.SetDataContentType(ctype)
+
+	if data, ok := value.(proto.Message); ok {
+		if message, err := anypb.New(data); err == nil {
+			x.SetDataSchema(message.TypeUrl)
+		}
+	}
+
+	return nil
+}
+
 // SetData encodes the given payload with the given content type. If the
 // provided payload is a byte array, when marshalled to json it will be encoded
 // as base64. If the provided payload is different from byte array,
@@ -295,7 +567,7 @@ func (x *Event) SetData(value interface{}) error {
 		}
 
 		x.SetDataSchema(message.TypeUrl)
-		x.SetDataContentType("application/cloudevents+protobuf")
+		x.SetDataContentType(ContentTypeCloudEventsProtobuf)
 		// set the data
 		x.Data = &Event_ProtoData{
 			ProtoData: message,
@@ -307,7 +579,10 @@ func (x *Event) SetData(value interface{}) error {
 	return nil
 }
 
-// GetOrderingKey returns the ordering key.
+// GetOrderingKey returns the event id as the ordering key.
+//
+// Deprecated: the id is unique per event, so it does not order anything. Use
+// the OrderingKeyFunc of the publisher instead.
 func (x *PushEventRequest) GetOrderingKey() string {
 	return x.Event.GetId()
 }
@@ -326,31 +601,34 @@ func (x *PushEventRequest) GetAttributes() map[string]string {
 	attributes[WithPrefix("specversion")] = x.Event.GetSpecVersion()
 
 	for name, attribute := range x.Event.GetAttributes() {
+		if attribute.GetKind() == AttributeKindUnknown {
+			continue
+		}
 		// prepare the name
 		name = WithPrefix(name)
 		// prepare the value
-		switch attr := attribute.Attr.(type) {
-		case *EventAttributeValue_CeBoolean:
-			attributes[name] = strconv.FormatBool(attr.CeBoolean)
-		case *EventAttributeValue_CeInteger:
-			attributes[name] = strconv.FormatInt(int64(attr.CeInteger), 10)
-		case *EventAttributeValue_CeBytes:
-			attributes[name] = base64.StdEncoding.EncodeToString(attr.CeBytes)
-		case *EventAttributeValue_CeUri:
-			attributes[name] = attr.CeUri
-		case *EventAttributeValue_CeUriRef:
-			attributes[name] = attr.CeUriRef
-		case *EventAttributeValue_CeTimestamp:
-			attributes[name] = attr.CeTimestamp.AsTime().UTC().Format(time.RFC3339Nano)
-		case *EventAttributeValue_CeString:
-			attributes[name] = attr.CeString
-		}
+		attributes[name] = attribute.Format()
+	}
+
+	return attributes
+}
+
+// GetTypedAttributes returns the attributes together with the AttributeKindsKey
+// attribute that records the type of every extension that is not a String.
+// SetAttributes uses it to restore the extensions with their original type.
+func (x *PushEventRequest) GetTypedAttributes() map[string]string {
+	attributes := x.GetAttributes()
+	// set the hint
+	if kinds := formatAttributeKinds(x.Event.GetAttributes()); kinds != "" {
+		attributes[AttributeKindsKey] = kinds
 	}
 
 	return attributes
 }
 
-// SetAttributes sets the attributes.
+// SetAttributes sets the attributes. The extensions are restored with the type
+// recorded in the AttributeKindsKey attribute, the type registered by
+// RegisterAttributeKind or as String attributes otherwise.
 func (x *PushEventRequest) SetAttributes(attributes map[string]string) error {
 	// WithPrefix returns the key without a prefix.
 	WithoutPrefix := func(key string) string {
@@ -359,7 +637,26 @@ func (x *PushEventRequest) SetAttributes(attributes map[string]string) error {
 		return key
 	}
 
+	if x.Event.Attributes == nil {
+		x.Event.Attributes = make(map[string]*EventAttributeValue)
+	}
+
+	kinds := make(map[string]AttributeKind)
+	// prepare the kinds
+	for name, value := range attributes {
+		if strings.EqualFold(name, AttributeKindsKey) {
+			var err error
+			// parse the kinds
+			if kinds, err = parseAttributeKinds(value); err != nil {
+				return err
+			}
+		}
+	}
+
 	for name, value := range attributes {
+		if strings.EqualFold(name, AttributeKindsKey) {
+			continue
+		}
 		// preapre the name
 		name = WithoutPrefix(name)
 		// prepare the value
@@ -386,7 +683,21 @@ func (x *PushEventRequest) SetAttributes(attributes map[string]string) error {
 			// set the value
 			x.Event.SetTime(timestamp)
 		default:
-			x.Event.SetExtension(name, value)
+			kind, ok := kinds[name]
+			if !ok {
+				kind, ok = LookupAttributeKind(name)
+			}
+
+			if !ok {
+				kind = AttributeKindString
+			}
+
+			attribute, err := ParseAttributeValue(kind, value)
+			if err != nil {
+				return fmt.Errorf("cannot set the attribute %q: %w", name, err)
+			}
+			// set the value
+			x.Event.Attributes[name] = attribute
 		}
 	}
 
@@ -413,7 +724,7 @@ func (x *PushEventRequest) SetData(data []byte) error {
 	ctype := x.Event.GetDataContentType()
 
 	switch {
-	case strings.EqualFold(ctype, "application/cloudevents+protobuf"):
+	case strings.EqualFold(ctype, ContentTypeCloudEventsProtobuf):
 		entity := &anypb.Any{}
 		// unmarshal the entity
 		if err := protojson.Unmarshal(data, entity); err != nil {
@@ -435,78 +746,10 @@ func (x *PushEventRequest) SetData(data []byte) error {
 		}
 	}
 
-	return nil
-}
-
-// GetEventID returns the event id from the incoming context.
-func GetEventID(ctx context.Context) string {
-	if values := metadata.ValueFromIncomingContext(ctx, "ce-id"); len(values) > 0 {
-		return values[0]
-	}
-
-	return ""
-}
-
-// GetEventType returns the event type from the incoming context.
-func GetEventType(ctx context.Context) string {
-	if values := metadata.ValueFromIncomingContext(ctx, "ce-type"); len(values) > 0 {
-		return values[0]
+	// keep the original content type
+	if ctype != "" {
+		x.Event.SetDataContentType(ctype)
 	}
 
-	return ""
-}
-
-// GetEventSource returns the event source from the incoming context.
-func GetEventSource(ctx context.Context) string {
-	if values := metadata.ValueFromIncomingContext(ctx, "ce-source"); len(values) > 0 {
-		return values[0]
-	}
-
-	return ""
-}
-
-// GetEventSubject returns the event subject from the incoming context.
-func GetEventSubject(ctx context.Context) string {
-	if values := metadata.ValueFromIncomingContext(ctx, "ce-subject"); len(values) > 0 {
-		return values[0]
-	}
-
-	return ""
-}
-
-// GetEventDataSchema returns the event data schema from the incoming context.
-func GetEventDataSchema(ctx context.Context) string {
-	if values := metadata.ValueFromIncomingContext(ctx, "ce-dataschema"); len(values) > 0 {
-		return values[0]
-	}
-
-	return ""
-}
-
-// GetEventDataContentType returns the event data schema from the incoming context.
-func GetEventDataContentType(ctx context.Context) string {
-	if values := metadata.ValueFromIncomingContext(ctx, "ce-datacontenttype"); len(values) > 0 {
-		return values[0]
-	}
-
-	return ""
-}
-
-// GetEventSpecVersion returns the event spec version from the incoming context.
-func GetEventSpecVersion(ctx context.Context) string {
-	if values := metadata.ValueFromIncomingContext(ctx, "ce-specversion"); len(values) > 0 {
-		return values[0]
-	}
-
-	return ""
-}
-
-// GetEventTime returns the event time from the incoming context.
-func GetEventTime(ctx context.Context) time.Time {
-	if values := metadata.ValueFromIncomingContext(ctx, "ce-time"); len(values) > 0 {
-		tvalue, _ := time.Parse(time.RFC3339, values[0])
-		return tvalue
-	}
-
-	return time.Time{}
+	return nil
 }
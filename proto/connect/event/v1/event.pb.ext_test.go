@@ -0,0 +1,265 @@
+package eventv1_test
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestPushEventRequestTypedAttributes(t *testing.T) {
+	event := newTestEvent(t)
+
+	extensions := map[string]*eventv1.EventAttributeValue{
+		"extbool":   {Attr: &eventv1.EventAttributeValue_CeBoolean{CeBoolean: true}},
+		"extint":    {Attr: &eventv1.EventAttributeValue_CeInteger{CeInteger: -42}},
+		"extstr":    {Attr: &eventv1.EventAttributeValue_CeString{CeString: "true"}},
+		"extbin":    {Attr: &eventv1.EventAttributeValue_CeBytes{CeBytes: []byte{0, 1, 2, 255}}},
+		"exturi":    {Attr: &eventv1.EventAttributeValue_CeUri{CeUri: "https://example.com/a?b=c"}},
+		"exturiref": {Attr: &eventv1.EventAttributeValue_CeUriRef{CeUriRef: "/a/b"}},
+		"extts":     {Attr: &eventv1.EventAttributeValue_CeTimestamp{CeTimestamp: timestamppb.New(time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC))}},
+	}
+
+	for name, value := range extensions {
+		event.Attributes[name] = value
+	}
+
+	if err := event.SetData("hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	args := &eventv1.PushEventRequest{Event: event}
+
+	attributes := args.GetTypedAttributes()
+
+	want := "extbin:Binary,extbool:Boolean,extint:Integer,extts:Timestamp,exturi:URI,exturiref:URI-reference"
+	if got := attributes[eventv1.AttributeKindsKey]; got != want {
+		t.Errorf("got hint %q, want %q", got, want)
+	}
+
+	decoded := &eventv1.PushEventRequest{Event: &eventv1.Event{}}
+	if err := decoded.SetAttributes(attributes); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := decoded.SetData(args.GetData()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded.Event) {
+		t.Errorf("got %v, want %v", decoded.Event, event)
+	}
+
+	if _, ok := decoded.Event.GetAttributes()[eventv1.AttributeKindsKey]; ok {
+		t.Error("the hint is restored as an attribute")
+	}
+}
+
+func TestPushEventRequestTypedAttributesRegistered(t *testing.T) {
+	registerTestAttributeKind(t, "testtypedregistered", eventv1.AttributeKindInteger)
+
+	event := newTestEvent(t)
+	if err := event.SetExtension("testtypedregistered", 7); err != nil {
+		t.Fatal(err)
+	}
+
+	args := &eventv1.PushEventRequest{Event: event}
+
+	attributes := args.GetTypedAttributes()
+	if hint, ok := attributes[eventv1.AttributeKindsKey]; ok {
+		t.Errorf("registered kinds are recorded in the hint %q", hint)
+	}
+
+	// the hint is missing, so the registry restores the kind
+	decoded := &eventv1.PushEventRequest{Event: &eventv1.Event{}}
+	if err := decoded.SetAttributes(attributes); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := decoded.Event.GetAttributes()["testtypedregistered"].GetCeInteger(); got != 7 {
+		t.Errorf("got %v, want 7", got)
+	}
+}
+
+func TestPushEventRequestSetAttributesWithoutHint(t *testing.T) {
+	args := &eventv1.PushEventRequest{Event: &eventv1.Event{}}
+
+	attributes := map[string]string{
+		"ce-specversion": "1.0",
+		"ce-id":          "1",
+		"ce-type":        "t",
+		"ce-source":      "/s",
+		"ce-extunknown":  "42",
+	}
+
+	if err := args.SetAttributes(attributes); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := args.Event.GetAttributes()["extunknown"].GetKind(); got != eventv1.AttributeKindString {
+		t.Errorf("got kind %v, want %v", got, eventv1.AttributeKindString)
+	}
+}
+
+func TestPushEventRequestSetAttributesInvalidHint(t *testing.T) {
+	cases := map[string]map[string]string{
+		"unknown kind": {
+			eventv1.AttributeKindsKey: "a:Float",
+		},
+		"invalid value": {
+			eventv1.AttributeKindsKey: "ext:Integer",
+			"ce-ext":                  "abc",
+		},
+	}
+
+	for name, attributes := range cases {
+		t.Run(name, func(t *testing.T) {
+			args := &eventv1.PushEventRequest{Event: &eventv1.Event{}}
+
+			if err := args.SetAttributes(attributes); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestEventSetExtension(t *testing.T) {
+	uri, _ := url.Parse("https://example.com/a")
+	ref, _ := url.Parse("/a")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		value any
+		kind  eventv1.AttributeKind
+	}{
+		{"bool", true, eventv1.AttributeKindBoolean},
+		{"string", "a", eventv1.AttributeKindString},
+		{"int", 42, eventv1.AttributeKindInteger},
+		{"int8", int8(-1), eventv1.AttributeKindInteger},
+		{"int64", int64(math.MinInt32), eventv1.AttributeKindInteger},
+		{"uint32", uint32(math.MaxInt32), eventv1.AttributeKindInteger},
+		{"bytes", []byte{1}, eventv1.AttributeKindBinary},
+		{"uri", uri, eventv1.AttributeKindURI},
+		{"uri value", *uri, eventv1.AttributeKindURI},
+		{"uri reference", ref, eventv1.AttributeKindURIRef},
+		{"time", now, eventv1.AttributeKindTimestamp},
+		{"timestamp", timestamppb.New(now), eventv1.AttributeKindTimestamp},
+		{"attribute", &eventv1.EventAttributeValue{Attr: &eventv1.EventAttributeValue_CeBoolean{}}, eventv1.AttributeKindBoolean},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			event := newTestEvent(t)
+
+			if err := event.SetExtension("ext", item.value); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := event.GetAttributes()["ext"].GetKind(); got != item.kind {
+				t.Errorf("got kind %v, want %v", got, item.kind)
+			}
+		})
+	}
+}
+
+func TestEventSetExtensionError(t *testing.T) {
+	cases := []struct {
+		name  string
+		value any
+	}{
+		{"int out of range", int64(math.MaxInt32) + 1},
+		{"uint out of range", uint64(math.MaxInt32) + 1},
+		{"float", 1.5},
+		{"nil uri", (*url.URL)(nil)},
+		{"nil timestamp", (*timestamppb.Timestamp)(nil)},
+		{"empty attribute", &eventv1.EventAttributeValue{}},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			event := newTestEvent(t)
+
+			if err := event.SetExtension("ext", item.value); err == nil {
+				t.Error("expected an error")
+			}
+
+			if _, ok := event.GetAttributes()["ext"]; ok {
+				t.Error("the extension is set")
+			}
+		})
+	}
+}
+
+func TestGetExtension(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	event := newTestEvent(t)
+	_ = event.SetExtension("extbool", true)
+	_ = event.SetExtension("extint", 42)
+	_ = event.SetExtension("extbytes", []byte{1, 2})
+	_ = event.SetExtension("extts", now)
+	event.Attributes["exturi"] = &eventv1.EventAttributeValue{Attr: &eventv1.EventAttributeValue_CeUri{CeUri: "https://example.com"}}
+
+	if got, err := eventv1.GetExtension[bool](event, "extbool"); err != nil || !got {
+		t.Errorf("got %v, %v, want true", got, err)
+	}
+
+	if got, err := eventv1.GetExtension[int64](event, "extint"); err != nil || got != 42 {
+		t.Errorf("got %v, %v, want 42", got, err)
+	}
+
+	if got, err := eventv1.GetExtension[string](event, "extint"); err != nil || got != "42" {
+		t.Errorf("got %q, %v, want 42", got, err)
+	}
+
+	if got, err := eventv1.GetExtension[[]byte](event, "extbytes"); err != nil || len(got) != 2 {
+		t.Errorf("got %v, %v, want [1 2]", got, err)
+	}
+
+	if got, err := eventv1.GetExtension[time.Time](event, "extts"); err != nil || !got.Equal(now) {
+		t.Errorf("got %v, %v, want %v", got, err, now)
+	}
+
+	if got, err := eventv1.GetExtension[*url.URL](event, "exturi"); err != nil || got.Host != "example.com" {
+		t.Errorf("got %v, %v, want example.com", got, err)
+	}
+
+	if _, err := eventv1.GetExtension[bool](event, "extint"); err == nil {
+		t.Error("expected a type mismatch error")
+	}
+
+	if _, err := eventv1.GetExtension[string](event, "missing"); !errors.Is(err, eventv1.ErrMissingExtension) {
+		t.Errorf("got %v, want %v", err, eventv1.ErrMissingExtension)
+	}
+}
+
+func TestEventExtensions(t *testing.T) {
+	event := newTestEvent(t)
+	event.SetDataContentType("text/plain")
+	_ = event.SetExtension("b", 1)
+	_ = event.SetExtension("a", 2)
+	_ = event.SetExtension("c", 3)
+
+	var names []string
+	for name := range event.Extensions() {
+		names = append(names, name)
+	}
+
+	if got := strings.Join(names, ","); got != "a,b,c" {
+		t.Errorf("got %v, want a,b,c", got)
+	}
+
+	event.DeleteExtension("b")
+
+	if _, ok := event.GetAttributes()["b"]; ok {
+		t.Error("the extension is not deleted")
+	}
+}
@@ -22,7 +22,25 @@ type EventServiceClient interface {
 	PushEvent(context.Context, *PushEventRequest) (*PushEventResponse, error)
 }
 
-var _ EventServiceClient = &NopEventServiceClient{}
+//counterfeiter:generate -o ./eventv1fake . AsyncEventServiceClient
+
+// The service client that an application uses to push events without waiting for each of them.
+type AsyncEventServiceClient interface {
+	// PushEventAsync pushes a given event to connect.runtime.v1.EventService service. It returns
+	// immediately with a result that is ready when the event is pushed.
+	PushEventAsync(context.Context, *PushEventRequest) *PushResult
+}
+
+//counterfeiter:generate -o ./eventv1fake . CloseableEventServiceClient
+
+// The service client that holds resources, such as a publisher, that must be released with Close.
+type CloseableEventServiceClient interface {
+	EventServiceClient
+	// Close flushes the pending events and releases the resources of the client.
+	Close(context.Context) error
+}
+
+var _ CloseableEventServiceClient = &NopEventServiceClient{}
 
 // NopEventServiceClient represents a no-op EventServiceClient.
 type NopEventServiceClient struct{}
@@ -31,3 +49,8 @@ type NopEventServiceClient struct{}
 func (*NopEventServiceClient) PushEvent(context.Context, *PushEventRequest) (*PushEventResponse, error) {
 	return &PushEventResponse{}, nil
 }
+
+// Close implements runtimev1.CloseableEventServiceClient.
+func (*NopEventServiceClient) Close(context.Context) error {
+	return nil
+}
@@ -197,6 +197,41 @@ func (m *PushEventResponse) validate(all bool) error {
 
 	var errors []error
 
+	// no validation rules for EventId
+
+	// no validation rules for MessageId
+
+	if all {
+		switch v := interface{}(m.GetAcceptTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PushEventResponseValidationError{
+					field:  "AcceptTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PushEventResponseValidationError{
+					field:  "AcceptTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAcceptTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PushEventResponseValidationError{
+				field:  "AcceptTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for SequenceNumber
+
 	if len(errors) > 0 {
 		return PushEventResponseMultiError(errors)
 	}
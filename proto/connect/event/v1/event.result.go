@@ -0,0 +1,67 @@
+package eventv1
+
+import (
+	"context"
+	"sync"
+)
+
+// PushResult represents the result of an event pushed by an
+// AsyncEventServiceClient.
+type PushResult struct {
+	ready   <-chan struct{}
+	resolve func() (string, *PushEventResponse, error)
+	once    sync.Once
+
+	messageID string
+	response  *PushEventResponse
+	err       error
+}
+
+// NewPushResult returns a new PushResult for the implementations of
+// AsyncEventServiceClient. The ready channel is closed when the push is
+// complete. The resolve function returns the message ID assigned by the
+// backend, the response and the error of the push. It is called at most once,
+// after the ready channel is closed.
+func NewPushResult(ready <-chan struct{}, resolve func() (string, *PushEventResponse, error)) *PushResult {
+	return &PushResult{
+		ready:   ready,
+		resolve: resolve,
+	}
+}
+
+// Ready returns a channel that is closed when the result is ready.
+func (x *PushResult) Ready() <-chan struct{} {
+	return x.ready
+}
+
+// Get waits for the result and returns the response of the push. It returns
+// the context error when the context is done before the result is ready.
+func (x *PushResult) Get(ctx context.Context) (*PushEventResponse, error) {
+	select {
+	case <-x.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	x.once.Do(func() {
+		x.messageID, x.response, x.err = x.resolve()
+	})
+
+	return x.response, x.err
+}
+
+// MessageID returns the message ID assigned by the backend. It returns an
+// empty string until the result is ready or when the push failed.
+func (x *PushResult) MessageID() string {
+	select {
+	case <-x.ready:
+	default:
+		return ""
+	}
+
+	x.once.Do(func() {
+		x.messageID, x.response, x.err = x.resolve()
+	})
+
+	return x.messageID
+}
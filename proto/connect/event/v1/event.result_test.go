@@ -0,0 +1,74 @@
+package eventv1_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestPushResult(t *testing.T) {
+	ready := make(chan struct{})
+	calls := 0
+
+	result := eventv1.NewPushResult(ready, func() (string, *eventv1.PushEventResponse, error) {
+		calls++
+		return "message-1", &eventv1.PushEventResponse{MessageId: "message-1"}, nil
+	})
+
+	if got := result.MessageID(); got != "" {
+		t.Errorf("got message id %q before the result is ready", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := result.Get(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+
+	select {
+	case <-result.Ready():
+		t.Fatal("the result is ready")
+	default:
+	}
+
+	close(ready)
+
+	response, err := result.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := response.GetMessageId(); got != "message-1" {
+		t.Errorf("got message id %q, want message-1", got)
+	}
+
+	if got := result.MessageID(); got != "message-1" {
+		t.Errorf("got message id %q, want message-1", got)
+	}
+
+	if calls != 1 {
+		t.Errorf("got %d resolve calls, want 1", calls)
+	}
+}
+
+func TestPushResultError(t *testing.T) {
+	ready := make(chan struct{})
+	close(ready)
+
+	want := errors.New("oops")
+
+	result := eventv1.NewPushResult(ready, func() (string, *eventv1.PushEventResponse, error) {
+		return "", nil, want
+	})
+
+	if _, err := result.Get(context.Background()); !errors.Is(err, want) {
+		t.Errorf("got %v, want %v", err, want)
+	}
+
+	if got := result.MessageID(); got != "" {
+		t.Errorf("got message id %q, want none", got)
+	}
+}
@@ -0,0 +1,185 @@
+package eventv1
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SpecVersion is the version of the CloudEvents specification that the events use.
+const SpecVersion = "1.0"
+
+var attributeNameRegexp = regexp.MustCompile("^[a-z0-9]+$")
+
+// ValidateSpec checks the field values on Event with the rules defined in the
+// proto definition and with the rules of the CloudEvents specification:
+//
+//   - id, source, type and specversion are required
+//   - specversion is 1.0
+//   - attribute names contain only lowercase letters and digits
+//   - extensions do not shadow the id, source, type and specversion attributes
+//   - time is a Timestamp, subject and datacontenttype are non-empty Strings,
+//     datacontenttype is an RFC 2046 type/subtype media type and dataschema is
+//     a URI
+//
+// The data is optional as defined by the specification, so the proto rule that
+// requires it is not enforced. The specification recommends attribute names of
+// at most 20 characters, but the longer names are accepted.
+//
+// The result is a list of violation errors wrapped in EventMultiError, or nil
+// if none found.
+func (x *Event) ValidateSpec() error {
+	var errors []error
+
+	if err := x.ValidateAll(); err != nil {
+		if multi, ok := err.(EventMultiError); ok {
+			for _, item := range multi.AllErrors() {
+				if verr, ok := item.(EventValidationError); ok && verr.Field() == "Data" && verr.Reason() == "value is required" {
+					continue
+				}
+
+				errors = append(errors, item)
+			}
+		} else {
+			errors = append(errors, err)
+		}
+	}
+
+	if x.GetId() == "" {
+		errors = append(errors, EventValidationError{
+			field:  "Id",
+			reason: "value is required",
+		})
+	}
+
+	if x.GetSource() == "" {
+		errors = append(errors, EventValidationError{
+			field:  "Source",
+			reason: "value is required",
+		})
+	}
+
+	if x.GetType() == "" {
+		errors = append(errors, EventValidationError{
+			field:  "Type",
+			reason: "value is required",
+		})
+	}
+
+	if x.GetSpecVersion() != SpecVersion {
+		errors = append(errors, EventValidationError{
+			field:  "SpecVersion",
+			reason: fmt.Sprintf("value must equal %q", SpecVersion),
+		})
+	}
+
+	names := make([]string, 0, len(x.GetAttributes()))
+	for name := range x.GetAttributes() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := fmt.Sprintf("Attributes[%v]", name)
+
+		if !attributeNameRegexp.MatchString(name) {
+			errors = append(errors, EventValidationError{
+				field:  field,
+				reason: "value must contain only lowercase letters and digits",
+				key:    true,
+			})
+		}
+
+		if reason := validateSpecAttribute(name, x.GetAttributes()[name]); reason != "" {
+			errors = append(errors, EventValidationError{
+				field:  field,
+				reason: reason,
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return EventMultiError(errors)
+	}
+
+	return nil
+}
+
+func validateSpecAttribute(name string, value *EventAttributeValue) string {
+	kind := value.GetKind()
+
+	switch name {
+	case "id", "source", "type", "specversion", "data", "data_base64":
+		return "value must not shadow a context attribute"
+	case "time":
+		if kind != AttributeKindTimestamp {
+			return "value must be a Timestamp"
+		}
+	case "subject":
+		if kind != AttributeKindString || value.GetCeString() == "" {
+			return "value must be a non-empty String"
+		}
+	case "dataschema":
+		if kind != AttributeKindURI {
+			return "value must be a URI"
+		}
+	case "datacontenttype":
+		if kind != AttributeKindString {
+			return "value must be a String"
+		}
+
+		mtype, _, err := mime.ParseMediaType(value.GetCeString())
+		if err != nil {
+			return "value must be a valid RFC 2046 media type"
+		}
+
+		if kind, subkind, ok := strings.Cut(mtype, "/"); !ok || kind == "" || subkind == "" || strings.Contains(subkind, "/") {
+			return "value must be a valid RFC 2046 media type"
+		}
+	}
+
+	return ""
+}
+
+// ValidateSpec checks the field values on PushEventRequest with the rules
+// defined in the proto definition and with the rules of the CloudEvents
+// specification as described by Event.ValidateSpec. The result is a list of
+// violation errors wrapped in PushEventRequestMultiError, or nil if none found.
+func (x *PushEventRequest) ValidateSpec() error {
+	if x.GetEvent() == nil {
+		return PushEventRequestMultiError{
+			PushEventRequestValidationError{
+				field:  "Event",
+				reason: "value is required",
+			},
+		}
+	}
+
+	err := x.GetEvent().ValidateSpec()
+	if err == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for _, item := range err.(EventMultiError).AllErrors() {
+		if verr, ok := item.(EventValidationError); ok {
+			errors = append(errors, PushEventRequestValidationError{
+				field:  "Event." + verr.Field(),
+				reason: verr.Reason(),
+				cause:  verr.Cause(),
+				key:    verr.Key(),
+			})
+		} else {
+			errors = append(errors, PushEventRequestValidationError{
+				field:  "Event",
+				reason: "embedded message failed validation",
+				cause:  item,
+			})
+		}
+	}
+
+	return PushEventRequestMultiError(errors)
+}
@@ -0,0 +1,102 @@
+package eventv1_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+func TestEventValidateSpec(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*eventv1.Event)
+	}{
+		{"without data", func(*eventv1.Event) {}},
+		{"with data", func(event *eventv1.Event) { _ = event.SetData("hello") }},
+		{"long attribute name", func(event *eventv1.Event) { _ = event.SetExtension("averyveryverylongextensionname", "a") }},
+		{"media type with parameters", func(event *eventv1.Event) { event.SetDataContentType("application/json; charset=utf-8") }},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			event := newTestEvent(t)
+			item.modify(event)
+
+			if err := event.ValidateSpec(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestEventValidateSpecError(t *testing.T) {
+	cases := []struct {
+		name   string
+		field  string
+		modify func(*eventv1.Event)
+	}{
+		{"missing id", "Id", func(event *eventv1.Event) { event.Id = "" }},
+		{"missing source", "Source", func(event *eventv1.Event) { event.SetSource("") }},
+		{"missing type", "Type", func(event *eventv1.Event) { event.SetType("") }},
+		{"spec version", "SpecVersion", func(event *eventv1.Event) { event.SpecVersion = "0.3" }},
+		{"attribute name", "Attributes[Ext]", func(event *eventv1.Event) { _ = event.SetExtension("Ext", "a") }},
+		{"shadowed attribute", "Attributes[id]", func(event *eventv1.Event) { _ = event.SetExtension("id", "a") }},
+		{"empty subject", "Attributes[subject]", func(event *eventv1.Event) { _ = event.SetExtension("subject", "") }},
+		{"time kind", "Attributes[time]", func(event *eventv1.Event) { _ = event.SetExtension("time", "now") }},
+		{"media type without subtype", "Attributes[datacontenttype]", func(event *eventv1.Event) { event.SetDataContentType("json") }},
+		{"media type with empty subtype", "Attributes[datacontenttype]", func(event *eventv1.Event) { event.SetDataContentType("application/") }},
+		{"invalid media type", "Attributes[datacontenttype]", func(event *eventv1.Event) { event.SetDataContentType("a b/c") }},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			event := newTestEvent(t)
+			item.modify(event)
+
+			err := event.ValidateSpec()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			var multi eventv1.EventMultiError
+			if !errors.As(err, &multi) {
+				t.Fatalf("got %T, want EventMultiError", err)
+			}
+
+			var fields []string
+			for _, item := range multi.AllErrors() {
+				var verr eventv1.EventValidationError
+				if errors.As(item, &verr) {
+					fields = append(fields, verr.Field())
+				}
+			}
+
+			if !strings.Contains(strings.Join(fields, ","), item.field) {
+				t.Errorf("got violations of %v, want %v", fields, item.field)
+			}
+		})
+	}
+}
+
+func TestPushEventRequestValidateSpec(t *testing.T) {
+	if err := (&eventv1.PushEventRequest{}).ValidateSpec(); err == nil {
+		t.Error("expected an error for a missing event")
+	}
+
+	event := newTestEvent(t)
+	event.SetType("")
+
+	err := (&eventv1.PushEventRequest{Event: event}).ValidateSpec()
+
+	var multi eventv1.PushEventRequestMultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("got %v, want PushEventRequestMultiError", err)
+	}
+
+	var verr eventv1.PushEventRequestValidationError
+	if !errors.As(multi.AllErrors()[0], &verr) || verr.Field() != "Event.Type" {
+		t.Errorf("got %v, want a violation of Event.Type", multi.AllErrors())
+	}
+}
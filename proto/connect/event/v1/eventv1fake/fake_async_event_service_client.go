@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package eventv1fake
+
+import (
+	"context"
+	"sync"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+type FakeAsyncEventServiceClient struct {
+	PushEventAsyncStub        func(context.Context, *eventv1.PushEventRequest) *eventv1.PushResult
+	pushEventAsyncMutex       sync.RWMutex
+	pushEventAsyncArgsForCall []struct {
+		arg1 context.Context
+		arg2 *eventv1.PushEventRequest
+	}
+	pushEventAsyncReturns struct {
+		result1 *eventv1.PushResult
+	}
+	pushEventAsyncReturnsOnCall map[int]struct {
+		result1 *eventv1.PushResult
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAsyncEventServiceClient) PushEventAsync(arg1 context.Context, arg2 *eventv1.PushEventRequest) *eventv1.PushResult {
+	fake.pushEventAsyncMutex.Lock()
+	ret, specificReturn := fake.pushEventAsyncReturnsOnCall[len(fake.pushEventAsyncArgsForCall)]
+	fake.pushEventAsyncArgsForCall = append(fake.pushEventAsyncArgsForCall, struct {
+		arg1 context.Context
+		arg2 *eventv1.PushEventRequest
+	}{arg1, arg2})
+	stub := fake.PushEventAsyncStub
+	fakeReturns := fake.pushEventAsyncReturns
+	fake.recordInvocation("PushEventAsync", []interface{}{arg1, arg2})
+	fake.pushEventAsyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAsyncEventServiceClient) PushEventAsyncCallCount() int {
+	fake.pushEventAsyncMutex.RLock()
+	defer fake.pushEventAsyncMutex.RUnlock()
+	return len(fake.pushEventAsyncArgsForCall)
+}
+
+func (fake *FakeAsyncEventServiceClient) PushEventAsyncCalls(stub func(context.Context, *eventv1.PushEventRequest) *eventv1.PushResult) {
+	fake.pushEventAsyncMutex.Lock()
+	defer fake.pushEventAsyncMutex.Unlock()
+	fake.PushEventAsyncStub = stub
+}
+
+func (fake *FakeAsyncEventServiceClient) PushEventAsyncArgsForCall(i int) (context.Context, *eventv1.PushEventRequest) {
+	fake.pushEventAsyncMutex.RLock()
+	defer fake.pushEventAsyncMutex.RUnlock()
+	argsForCall := fake.pushEventAsyncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAsyncEventServiceClient) PushEventAsyncReturns(result1 *eventv1.PushResult) {
+	fake.pushEventAsyncMutex.Lock()
+	defer fake.pushEventAsyncMutex.Unlock()
+	fake.PushEventAsyncStub = nil
+	fake.pushEventAsyncReturns = struct {
+		result1 *eventv1.PushResult
+	}{result1}
+}
+
+func (fake *FakeAsyncEventServiceClient) PushEventAsyncReturnsOnCall(i int, result1 *eventv1.PushResult) {
+	fake.pushEventAsyncMutex.Lock()
+	defer fake.pushEventAsyncMutex.Unlock()
+	fake.PushEventAsyncStub = nil
+	if fake.pushEventAsyncReturnsOnCall == nil {
+		fake.pushEventAsyncReturnsOnCall = make(map[int]struct {
+			result1 *eventv1.PushResult
+		})
+	}
+	fake.pushEventAsyncReturnsOnCall[i] = struct {
+		result1 *eventv1.PushResult
+	}{result1}
+}
+
+func (fake *FakeAsyncEventServiceClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pushEventAsyncMutex.RLock()
+	defer fake.pushEventAsyncMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAsyncEventServiceClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ eventv1.AsyncEventServiceClient = new(FakeAsyncEventServiceClient)
@@ -0,0 +1,193 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package eventv1fake
+
+import (
+	"context"
+	"sync"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+type FakeCloseableEventServiceClient struct {
+	CloseStub        func(context.Context) error
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+		arg1 context.Context
+	}
+	closeReturns struct {
+		result1 error
+	}
+	closeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PushEventStub        func(context.Context, *eventv1.PushEventRequest) (*eventv1.PushEventResponse, error)
+	pushEventMutex       sync.RWMutex
+	pushEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 *eventv1.PushEventRequest
+	}
+	pushEventReturns struct {
+		result1 *eventv1.PushEventResponse
+		result2 error
+	}
+	pushEventReturnsOnCall map[int]struct {
+		result1 *eventv1.PushEventResponse
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCloseableEventServiceClient) Close(arg1 context.Context) error {
+	fake.closeMutex.Lock()
+	ret, specificReturn := fake.closeReturnsOnCall[len(fake.closeArgsForCall)]
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.CloseStub
+	fakeReturns := fake.closeReturns
+	fake.recordInvocation("Close", []interface{}{arg1})
+	fake.closeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCloseableEventServiceClient) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeCloseableEventServiceClient) CloseCalls(stub func(context.Context) error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *FakeCloseableEventServiceClient) CloseArgsForCall(i int) context.Context {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	argsForCall := fake.closeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCloseableEventServiceClient) CloseReturns(result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	fake.closeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCloseableEventServiceClient) CloseReturnsOnCall(i int, result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	if fake.closeReturnsOnCall == nil {
+		fake.closeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.closeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCloseableEventServiceClient) PushEvent(arg1 context.Context, arg2 *eventv1.PushEventRequest) (*eventv1.PushEventResponse, error) {
+	fake.pushEventMutex.Lock()
+	ret, specificReturn := fake.pushEventReturnsOnCall[len(fake.pushEventArgsForCall)]
+	fake.pushEventArgsForCall = append(fake.pushEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 *eventv1.PushEventRequest
+	}{arg1, arg2})
+	stub := fake.PushEventStub
+	fakeReturns := fake.pushEventReturns
+	fake.recordInvocation("PushEvent", []interface{}{arg1, arg2})
+	fake.pushEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCloseableEventServiceClient) PushEventCallCount() int {
+	fake.pushEventMutex.RLock()
+	defer fake.pushEventMutex.RUnlock()
+	return len(fake.pushEventArgsForCall)
+}
+
+func (fake *FakeCloseableEventServiceClient) PushEventCalls(stub func(context.Context, *eventv1.PushEventRequest) (*eventv1.PushEventResponse, error)) {
+	fake.pushEventMutex.Lock()
+	defer fake.pushEventMutex.Unlock()
+	fake.PushEventStub = stub
+}
+
+func (fake *FakeCloseableEventServiceClient) PushEventArgsForCall(i int) (context.Context, *eventv1.PushEventRequest) {
+	fake.pushEventMutex.RLock()
+	defer fake.pushEventMutex.RUnlock()
+	argsForCall := fake.pushEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCloseableEventServiceClient) PushEventReturns(result1 *eventv1.PushEventResponse, result2 error) {
+	fake.pushEventMutex.Lock()
+	defer fake.pushEventMutex.Unlock()
+	fake.PushEventStub = nil
+	fake.pushEventReturns = struct {
+		result1 *eventv1.PushEventResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloseableEventServiceClient) PushEventReturnsOnCall(i int, result1 *eventv1.PushEventResponse, result2 error) {
+	fake.pushEventMutex.Lock()
+	defer fake.pushEventMutex.Unlock()
+	fake.PushEventStub = nil
+	if fake.pushEventReturnsOnCall == nil {
+		fake.pushEventReturnsOnCall = make(map[int]struct {
+			result1 *eventv1.PushEventResponse
+			result2 error
+		})
+	}
+	fake.pushEventReturnsOnCall[i] = struct {
+		result1 *eventv1.PushEventResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloseableEventServiceClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	fake.pushEventMutex.RLock()
+	defer fake.pushEventMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCloseableEventServiceClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ eventv1.CloseableEventServiceClient = new(FakeCloseableEventServiceClient)
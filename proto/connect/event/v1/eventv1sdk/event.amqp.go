@@ -0,0 +1,168 @@
+package eventv1sdk
+
+import (
+	mime "mime"
+	strings "strings"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+// AMQPMessage represents an AMQP 1.0 message. It does not depend on a
+// particular AMQP client, the caller copies the fields to and from the client
+// message.
+type AMQPMessage struct {
+	// ContentType is the content-type message property.
+	ContentType string
+	// ApplicationProperties contains the application properties.
+	ApplicationProperties map[string]any
+	// Data is the application data section.
+	Data []byte
+}
+
+// WriteAMQPMessage writes the event to the given message in the given content
+// mode. In the binary mode the attributes are written as cloudEvents_
+// application properties with their native AMQP types, the type of the
+// extensions as the AttributeKindsKey application property and the data
+// content type as the content-type message property.
+func WriteAMQPMessage(msg *AMQPMessage, event *eventv1.Event, mode ContentMode) error {
+	switch mode {
+	case ContentModeStructured:
+		data, err := event.MarshalCloudEventJSON()
+		if err != nil {
+			return err
+		}
+
+		msg.Data = data
+		msg.ContentType = eventv1.ContentTypeCloudEventsJSON
+	case ContentModeBinary:
+		if msg.ApplicationProperties == nil {
+			msg.ApplicationProperties = make(map[string]any)
+		}
+
+		args := &eventv1.PushEventRequest{
+			Event: event,
+		}
+
+		properties := msg.ApplicationProperties
+		properties["cloudEvents_id"] = event.GetId()
+		properties["cloudEvents_type"] = event.GetType()
+		properties["cloudEvents_source"] = event.GetSource()
+		properties["cloudEvents_specversion"] = event.GetSpecVersion()
+
+		for name, attribute := range event.GetAttributes() {
+			if name == "datacontenttype" {
+				continue
+			}
+			// prepare the name
+			name = "cloudEvents_" + name
+			// prepare the value
+			switch attr := attribute.GetAttr().(type) {
+			case *eventv1.EventAttributeValue_CeBoolean:
+				properties[name] = attr.CeBoolean
+			case *eventv1.EventAttributeValue_CeInteger:
+				properties[name] = attr.CeInteger
+			case *eventv1.EventAttributeValue_CeBytes:
+				properties[name] = attr.CeBytes
+			case *eventv1.EventAttributeValue_CeTimestamp:
+				properties[name] = attr.CeTimestamp.AsTime()
+			case nil:
+				continue
+			default:
+				properties[name] = attribute.Format()
+			}
+		}
+
+		// URI and URI-reference have no AMQP type
+		if hint, ok := args.GetTypedAttributes()[eventv1.AttributeKindsKey]; ok {
+			properties[eventv1.AttributeKindsKey] = hint
+		}
+
+		msg.Data = args.GetData()
+		msg.ContentType = event.GetDataContentType()
+	default:
+		return ErrUnsupportedContentMode
+	}
+
+	return nil
+}
+
+// ReadAMQPMessage reads the event from the given message. The content mode is
+// detected from the content-type message property and the specversion
+// application property. Both the cloudEvents_ and the cloudEvents: prefixes
+// are accepted.
+func ReadAMQPMessage(msg *AMQPMessage) (*eventv1.Event, error) {
+	mtype, _, _ := mime.ParseMediaType(msg.ContentType)
+
+	if strings.EqualFold(mtype, eventv1.ContentTypeCloudEventsJSON) {
+		event := &eventv1.Event{}
+		// unmarshal the event
+		if err := event.UnmarshalCloudEventJSON(msg.Data); err != nil {
+			return nil, err
+		}
+
+		return event, nil
+	}
+
+	var (
+		attributes = make(map[string]string)
+		values     = make(map[string]any)
+	)
+
+	for key, value := range msg.ApplicationProperties {
+		if hint, ok := value.(string); ok && strings.EqualFold(key, eventv1.AttributeKindsKey) {
+			attributes[eventv1.AttributeKindsKey] = hint
+			continue
+		}
+
+		name, ok := trimAMQPPrefix(key)
+		if !ok {
+			continue
+		}
+
+		if text, ok := value.(string); ok {
+			attributes["ce-"+name] = text
+		} else {
+			values[name] = value
+		}
+	}
+
+	if _, ok := attributes["ce-specversion"]; !ok {
+		return nil, ErrMissingEvent
+	}
+
+	if msg.ContentType != "" {
+		attributes["ce-datacontenttype"] = msg.ContentType
+	}
+
+	args := &eventv1.PushEventRequest{
+		Event: &eventv1.Event{},
+	}
+
+	// set the event attributes
+	if err := args.SetAttributes(attributes); err != nil {
+		return nil, err
+	}
+
+	for name, value := range values {
+		if err := args.Event.SetExtension(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	// set the event data
+	if err := args.SetData(msg.Data); err != nil {
+		return nil, err
+	}
+
+	return args.Event, nil
+}
+
+func trimAMQPPrefix(key string) (string, bool) {
+	for _, prefix := range []string{"cloudEvents_", "cloudEvents:"} {
+		if len(key) > len(prefix) && strings.EqualFold(key[:len(prefix)], prefix) {
+			return strings.ToLower(key[len(prefix):]), true
+		}
+	}
+
+	return "", false
+}
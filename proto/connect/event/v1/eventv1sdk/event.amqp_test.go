@@ -0,0 +1,163 @@
+package eventv1sdk_test
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func TestAMQPMessage(t *testing.T) {
+	cases := []struct {
+		name       string
+		mode       eventv1sdk.ContentMode
+		extensions map[string]any
+	}{
+		{
+			name: "binary",
+			mode: eventv1sdk.ContentModeBinary,
+			extensions: map[string]any{
+				"extbool":  true,
+				"extint":   int32(42),
+				"extbytes": []byte{0, 1, 2},
+				"extts":    time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+			},
+		},
+		{
+			// JSON restores the other types only when they are registered
+			name: "structured",
+			mode: eventv1sdk.ContentModeStructured,
+			extensions: map[string]any{
+				"extbool": true,
+				"extint":  int32(42),
+			},
+		},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			event := newTestEvent(t)
+
+			for name, value := range item.extensions {
+				if err := event.SetExtension(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			msg := &eventv1sdk.AMQPMessage{}
+			if err := eventv1sdk.WriteAMQPMessage(msg, event, item.mode); err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := eventv1sdk.ReadAMQPMessage(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !proto.Equal(event, decoded) {
+				t.Errorf("got %v, want %v", decoded, event)
+			}
+		})
+	}
+}
+
+func TestAMQPMessageTypedExtensions(t *testing.T) {
+	event := newTestEvent(t)
+
+	event.Attributes["exturi"] = &eventv1.EventAttributeValue{
+		Attr: &eventv1.EventAttributeValue_CeUri{CeUri: "https://example.com/a"},
+	}
+
+	event.Attributes["exturiref"] = &eventv1.EventAttributeValue{
+		Attr: &eventv1.EventAttributeValue_CeUriRef{CeUriRef: "/a/b"},
+	}
+
+	msg := &eventv1sdk.AMQPMessage{}
+	if err := eventv1sdk.WriteAMQPMessage(msg, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := msg.ApplicationProperties["cloudEvents_exturi"]; got != "https://example.com/a" {
+		t.Errorf("got %#v, want the URI string", got)
+	}
+
+	decoded, err := eventv1sdk.ReadAMQPMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded) {
+		t.Errorf("got %v, want %v", decoded, event)
+	}
+}
+
+func TestAMQPMessageBinaryProperties(t *testing.T) {
+	event := newTestEvent(t)
+
+	if err := event.SetExtension("extint", 42); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := &eventv1sdk.AMQPMessage{}
+	if err := eventv1sdk.WriteAMQPMessage(msg, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := msg.ApplicationProperties["cloudEvents_extint"].(int32); !ok || got != 42 {
+		t.Errorf("got %#v, want the native int32 42", msg.ApplicationProperties["cloudEvents_extint"])
+	}
+
+	if got := msg.ApplicationProperties["cloudEvents_subject"]; got != event.GetSubject() {
+		t.Errorf("got subject %v, want %v", got, event.GetSubject())
+	}
+
+	if _, ok := msg.ApplicationProperties["cloudEvents_datacontenttype"]; ok {
+		t.Error("the data content type is written as an application property")
+	}
+
+	if msg.ContentType != "application/json" {
+		t.Errorf("got content type %q, want application/json", msg.ContentType)
+	}
+}
+
+func TestAMQPMessageColonPrefix(t *testing.T) {
+	msg := &eventv1sdk.AMQPMessage{
+		ApplicationProperties: map[string]any{
+			"cloudEvents:specversion": "1.0",
+			"cloudEvents:id":          "1",
+			"cloudEvents:type":        "com.example.test",
+			"cloudEvents:source":      "/test",
+			"cloudEvents:extbool":     true,
+			"other":                   "ignored",
+		},
+		Data: []byte("hello"),
+	}
+
+	event, err := eventv1sdk.ReadAMQPMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.GetId() != "1" || event.GetType() != "com.example.test" {
+		t.Errorf("got %v", event)
+	}
+
+	if got := event.GetAttributes()["extbool"].GetCeBoolean(); !got {
+		t.Errorf("got extbool %v, want true", got)
+	}
+
+	if _, ok := event.GetAttributes()["other"]; ok {
+		t.Error("a non-CloudEvents property is read as an attribute")
+	}
+}
+
+func TestAMQPMessageMissingEvent(t *testing.T) {
+	msg := &eventv1sdk.AMQPMessage{Data: []byte("hello")}
+
+	if _, err := eventv1sdk.ReadAMQPMessage(msg); err != eventv1sdk.ErrMissingEvent {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrMissingEvent)
+	}
+}
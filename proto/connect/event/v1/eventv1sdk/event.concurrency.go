@@ -0,0 +1,235 @@
+package eventv1sdk
+
+import (
+	context "context"
+	fmt "fmt"
+	sync "sync"
+	time "time"
+
+	connect "connectrpc.com/connect"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+// OrderingKeyFunc returns the ordering key of the event. An empty key means
+// that the event has no ordering requirements.
+type OrderingKeyFunc func(*eventv1.Event) string
+
+var (
+	// OrderingKeyNone disables the ordering. It is a nil function, so it must
+	// not be called.
+	OrderingKeyNone OrderingKeyFunc
+	// OrderingKeySubject returns the subject attribute as the ordering key.
+	OrderingKeySubject OrderingKeyFunc = func(event *eventv1.Event) string { return event.GetSubject() }
+	// OrderingKeyPartitionKey returns the partitionkey extension as the ordering key.
+	OrderingKeyPartitionKey OrderingKeyFunc = func(event *eventv1.Event) string {
+		key, _ := eventv1.GetExtension[string](event, "partitionkey")
+		return key
+	}
+)
+
+var (
+	// ErrTooManyEvents is returned by the concurrency middleware when the
+	// concurrency limit is saturated.
+	ErrTooManyEvents = fmt.Errorf("too many events")
+	// ErrOutOfOrder is returned by the concurrency middleware when an earlier
+	// event with the same ordering key has been rejected.
+	ErrOutOfOrder = fmt.Errorf("out of order")
+)
+
+// ConcurrencyConfig represents a configuration for the concurrency middleware.
+type ConcurrencyConfig struct {
+	// Limit is the maximum number of events that are handled at the same
+	// time. Zero means no limit.
+	Limit int
+	// Wait is the time that an event waits for a free slot when the limit is
+	// saturated. Zero means that the event is rejected immediately.
+	Wait time.Duration
+	// RetryAfter is the suggested delay of the rejected events. It defaults
+	// to one second.
+	RetryAfter time.Duration
+	// OrderingKey returns the ordering key of the event. The events with the
+	// same ordering key are handled one at a time in the order of their
+	// arrival. It is optional.
+	OrderingKey OrderingKeyFunc
+}
+
+// WithConcurrency limits the number of events that the handler handles at the
+// same time. An event that cannot get a free slot is rejected with a retryable
+// ErrTooManyEvents and connect.CodeResourceExhausted. When the OrderingKey is
+// set, the events with the same ordering key are serialized, so a single event
+// per key is handled at a time. An event takes its slot before it waits for
+// its turn, so the waiting events are bounded by the limit as well. When an
+// event of a key is rejected, the events of the key that arrive after it are
+// rejected with a retryable ErrOutOfOrder and connect.CodeAborted until the
+// events of the key that are ahead of it are handled.
+func WithConcurrency(config *ConcurrencyConfig) eventv1.Middleware {
+	var slots chan struct{}
+
+	if config.Limit > 0 {
+		slots = make(chan struct{}, config.Limit)
+	}
+
+	retryAfter := config.RetryAfter
+	if retryAfter == 0 {
+		retryAfter = time.Second
+	}
+
+	queue := &orderingQueue{
+		keys:       make(map[string]*orderingState),
+		retryAfter: retryAfter,
+	}
+
+	innerFn := func(next eventv1.EventHandler) eventv1.EventHandler {
+		fn := func(ctx context.Context, event *eventv1.Event) error {
+			if slots != nil {
+				if !acquireSlot(ctx, slots, config.Wait) {
+					return eventv1.Retryable(connect.NewError(connect.CodeResourceExhausted, ErrTooManyEvents), retryAfter)
+				}
+				defer func() { <-slots }()
+			}
+
+			if config.OrderingKey != nil {
+				if key := config.OrderingKey(event); key != "" {
+					leave, err := queue.enter(ctx, key)
+					if err != nil {
+						return err
+					}
+					defer leave()
+				}
+			}
+
+			return next.HandleEvent(ctx, event)
+		}
+
+		return eventv1.EventHandlerFunc(fn)
+	}
+
+	return innerFn
+}
+
+// acquireSlot acquires a slot within the given time.
+func acquireSlot(ctx context.Context, slots chan struct{}, wait time.Duration) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if wait <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// orderingQueue serializes the events with the same ordering key. Every key
+// has a chain of entries where each event waits for the entry of its
+// predecessor and closes its own entry when it leaves. A rejected entry
+// rejects its successors, so the events of a key are never handled out of
+// order.
+type orderingQueue struct {
+	mu         sync.Mutex
+	keys       map[string]*orderingState
+	retryAfter time.Duration
+}
+
+// orderingState represents the queue of a single key.
+type orderingState struct {
+	// tail is the entry of the last event.
+	tail *orderingEntry
+	// size is the number of the events in the queue.
+	size int
+	// rejecting reports whether an event of the queue has been rejected.
+	rejecting bool
+}
+
+// orderingEntry represents an event in the queue of a key.
+type orderingEntry struct {
+	// done is closed when the event leaves the queue.
+	done chan struct{}
+	// rejected reports whether the event has been rejected. It is set before
+	// done is closed.
+	rejected bool
+}
+
+// enter waits for the turn of the caller. The caller must call the returned
+// function when it is done.
+func (x *orderingQueue) enter(ctx context.Context, key string) (func(), error) {
+	x.mu.Lock()
+
+	state, ok := x.keys[key]
+	if !ok {
+		state = &orderingState{}
+		x.keys[key] = state
+	}
+
+	if state.rejecting {
+		x.mu.Unlock()
+		return nil, x.outOfOrder()
+	}
+
+	entry := &orderingEntry{
+		done: make(chan struct{}),
+	}
+
+	prev := state.tail
+	state.tail = entry
+	state.size++
+
+	x.mu.Unlock()
+
+	leave := func() {
+		x.mu.Lock()
+		// forget the key when the queue drains
+		if state.size--; state.size == 0 {
+			delete(x.keys, key)
+		}
+		x.mu.Unlock()
+		// wake up the successor
+		close(entry.done)
+	}
+
+	if prev == nil {
+		return leave, nil
+	}
+
+	select {
+	case <-prev.done:
+		if prev.rejected {
+			entry.rejected = true
+			leave()
+
+			return nil, x.outOfOrder()
+		}
+
+		return leave, nil
+	case <-ctx.Done():
+		x.mu.Lock()
+		state.rejecting = true
+		x.mu.Unlock()
+
+		entry.rejected = true
+		// keep the chain intact for the successors
+		go func() {
+			<-prev.done
+			leave()
+		}()
+
+		return nil, eventv1.Retryable(connect.NewError(connect.CodeResourceExhausted, ctx.Err()), 0)
+	}
+}
+
+func (x *orderingQueue) outOfOrder() error {
+	return eventv1.Retryable(connect.NewError(connect.CodeAborted, ErrOutOfOrder), x.retryAfter)
+}
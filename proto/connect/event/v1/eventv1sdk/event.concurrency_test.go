@@ -0,0 +1,250 @@
+package eventv1sdk_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+// blockingHandler blocks the events until their subject is released.
+type blockingHandler struct {
+	mu      sync.Mutex
+	started chan string
+	release map[string]chan struct{}
+	order   []string
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{
+		started: make(chan string, 16),
+		release: make(map[string]chan struct{}),
+	}
+}
+
+func (x *blockingHandler) gate(id string) chan struct{} {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if _, ok := x.release[id]; !ok {
+		x.release[id] = make(chan struct{})
+	}
+
+	return x.release[id]
+}
+
+func (x *blockingHandler) HandleEvent(_ context.Context, event *eventv1.Event) error {
+	x.mu.Lock()
+	x.order = append(x.order, event.GetId())
+	x.mu.Unlock()
+
+	x.started <- event.GetId()
+	<-x.gate(event.GetId())
+
+	return nil
+}
+
+func newTestKeyedEvent(t *testing.T, id, key string) *eventv1.Event {
+	t.Helper()
+
+	event := newTestEvent(t)
+	event.SetId(id)
+	event.SetSubject(key)
+
+	return event
+}
+
+func handleAsync(ctx context.Context, handler eventv1.EventHandler, event *eventv1.Event) chan error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- handler.HandleEvent(ctx, event)
+	}()
+
+	return done
+}
+
+func TestWithConcurrencyLimit(t *testing.T) {
+	next := newBlockingHandler()
+
+	handler := eventv1.Chain(next, eventv1sdk.WithConcurrency(&eventv1sdk.ConcurrencyConfig{
+		Limit:      1,
+		RetryAfter: 3 * time.Second,
+	}))
+
+	first := handleAsync(context.Background(), handler, newTestKeyedEvent(t, "a", ""))
+	<-next.started
+
+	err := handler.HandleEvent(context.Background(), newTestKeyedEvent(t, "b", ""))
+	if !errors.Is(err, eventv1sdk.ErrTooManyEvents) || connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrTooManyEvents)
+	}
+
+	if after, ok := eventv1.RetryAfterOf(err); !ok || after != 3*time.Second {
+		t.Errorf("got retry after %v, want 3s", after)
+	}
+
+	close(next.gate("a"))
+
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithConcurrencyWait(t *testing.T) {
+	next := newBlockingHandler()
+
+	handler := eventv1.Chain(next, eventv1sdk.WithConcurrency(&eventv1sdk.ConcurrencyConfig{
+		Limit: 1,
+		Wait:  5 * time.Second,
+	}))
+
+	first := handleAsync(context.Background(), handler, newTestKeyedEvent(t, "a", ""))
+	<-next.started
+
+	second := handleAsync(context.Background(), handler, newTestKeyedEvent(t, "b", ""))
+
+	close(next.gate("a"))
+	<-next.started
+	close(next.gate("b"))
+
+	for _, done := range []chan error{first, second} {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWithConcurrencyOrderingKey(t *testing.T) {
+	next := newBlockingHandler()
+
+	handler := eventv1.Chain(next, eventv1sdk.WithConcurrency(&eventv1sdk.ConcurrencyConfig{
+		OrderingKey: eventv1sdk.OrderingKeySubject,
+	}))
+
+	var results []chan error
+
+	for _, id := range []string{"a1", "a2", "a3"} {
+		results = append(results, handleAsync(context.Background(), handler, newTestKeyedEvent(t, id, "a")))
+		// let the event enter the queue
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the events of another key are not blocked
+	other := handleAsync(context.Background(), handler, newTestKeyedEvent(t, "b1", "b"))
+
+	started := map[string]bool{<-next.started: true, <-next.started: true}
+	if !started["a1"] || !started["b1"] {
+		t.Fatalf("got started %v, want a1 and b1", started)
+	}
+
+	close(next.gate("b1"))
+
+	for _, id := range []string{"a1", "a2", "a3"} {
+		close(next.gate(id))
+	}
+
+	for _, done := range append(results, other) {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var order []string
+	for _, id := range next.order {
+		if id != "b1" {
+			order = append(order, id)
+		}
+	}
+
+	if len(order) != 3 || order[0] != "a1" || order[1] != "a2" || order[2] != "a3" {
+		t.Errorf("got order %v, want [a1 a2 a3]", order)
+	}
+}
+
+func TestWithConcurrencyOrderingKeyTakesSlot(t *testing.T) {
+	next := newBlockingHandler()
+
+	handler := eventv1.Chain(next, eventv1sdk.WithConcurrency(&eventv1sdk.ConcurrencyConfig{
+		Limit:       2,
+		OrderingKey: eventv1sdk.OrderingKeySubject,
+	}))
+
+	first := handleAsync(context.Background(), handler, newTestKeyedEvent(t, "a1", "a"))
+	<-next.started
+
+	// the waiting event holds the second slot
+	second := handleAsync(context.Background(), handler, newTestKeyedEvent(t, "a2", "a"))
+	time.Sleep(10 * time.Millisecond)
+
+	err := handler.HandleEvent(context.Background(), newTestKeyedEvent(t, "b1", "b"))
+	if !errors.Is(err, eventv1sdk.ErrTooManyEvents) {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrTooManyEvents)
+	}
+
+	close(next.gate("a1"))
+	close(next.gate("a2"))
+
+	for _, done := range []chan error{first, second} {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWithConcurrencyOrderingKeyRejectsTail(t *testing.T) {
+	next := newBlockingHandler()
+
+	handler := eventv1.Chain(next, eventv1sdk.WithConcurrency(&eventv1sdk.ConcurrencyConfig{
+		OrderingKey: eventv1sdk.OrderingKeySubject,
+	}))
+
+	first := handleAsync(context.Background(), handler, newTestKeyedEvent(t, "a1", "a"))
+	<-next.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// the second event gives up while it waits for its turn
+	second := handleAsync(ctx, handler, newTestKeyedEvent(t, "a2", "a"))
+	time.Sleep(10 * time.Millisecond)
+	// the third event waits behind the second one
+	third := handleAsync(context.Background(), handler, newTestKeyedEvent(t, "a3", "a"))
+
+	if err := <-second; connect.CodeOf(err) != connect.CodeResourceExhausted || eventv1.KindOf(err) != eventv1.ErrorKindRetryable {
+		t.Errorf("got %v, want a retryable %v", err, connect.CodeResourceExhausted)
+	}
+
+	// a new event of the key is rejected while the queue is rejecting
+	err := handler.HandleEvent(context.Background(), newTestKeyedEvent(t, "a4", "a"))
+	if !errors.Is(err, eventv1sdk.ErrOutOfOrder) || connect.CodeOf(err) != connect.CodeAborted {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrOutOfOrder)
+	}
+
+	close(next.gate("a1"))
+
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-third; !errors.Is(err, eventv1sdk.ErrOutOfOrder) {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrOutOfOrder)
+	}
+
+	// the queue has drained, so the key accepts the events again
+	close(next.gate("a5"))
+
+	if err := handler.HandleEvent(context.Background(), newTestKeyedEvent(t, "a5", "a")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if got := len(next.order); got != 2 {
+		t.Errorf("got %d handled events %v, want 2", got, next.order)
+	}
+}
@@ -0,0 +1,89 @@
+package eventv1sdk
+
+import (
+	context "context"
+	slog "log/slog"
+	time "time"
+
+	slogr "github.com/ralch/slogr"
+	proto "google.golang.org/protobuf/proto"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+const (
+	// DeadLetterErrorKey is the extension that contains the error message of
+	// a dead-letter event.
+	DeadLetterErrorKey = "deadlettererror"
+	// DeadLetterHandlerKey is the extension that contains the name of the
+	// handler that failed to handle a dead-letter event.
+	DeadLetterHandlerKey = "deadletterhandler"
+	// DeadLetterAttemptKey is the extension that contains the number of
+	// attempts to handle a dead-letter event.
+	DeadLetterAttemptKey = "deadletterattempt"
+	// DeadLetterTimeKey is the extension that contains the time when the
+	// handling of a dead-letter event failed.
+	DeadLetterTimeKey = "deadlettertime"
+)
+
+var _ eventv1.ErrorHandler = &DeadLetterErrorHandler{}
+
+// DeadLetterErrorHandler is an eventv1.ErrorHandler that pushes the events that
+// fail permanently to a dead-letter EventService. The dead-letter event keeps
+// the id, the source and the data of the original event, and describes the
+// failure in the deadletter* extensions.
+type DeadLetterErrorHandler struct {
+	// EventServiceClient contains a client of the dead-letter EventService.
+	EventServiceClient eventv1.EventServiceClient
+	// Handler is the name of the failed handler. It is optional.
+	Handler string
+}
+
+// HandleError implements eventv1.ErrorHandler.
+func (x *DeadLetterErrorHandler) HandleError(ctx context.Context, event *eventv1.Event, err error) error {
+	attempt := eventv1.GetEventAttempt(ctx)
+	if attempt == 0 {
+		attempt = 1
+	}
+
+	// prepare the event
+	letter := proto.Clone(event).(*eventv1.Event)
+
+	extensions := map[string]any{
+		DeadLetterErrorKey:   err.Error(),
+		DeadLetterAttemptKey: attempt,
+		DeadLetterTimeKey:    time.Now(),
+	}
+
+	if x.Handler != "" {
+		extensions[DeadLetterHandlerKey] = x.Handler
+	}
+
+	for name, value := range extensions {
+		if err := letter.SetExtension(name, value); err != nil {
+			return err
+		}
+	}
+
+	// prepare the logger attr
+	attr := slog.Group("deadletter",
+		slog.String("error", err.Error()),
+		slog.String("handler", x.Handler),
+		slog.Int("attempt", attempt),
+	)
+
+	logger := slogr.FromContext(ctx)
+	logger.InfoContext(ctx, "push a dead-letter event", attr)
+
+	args := &eventv1.PushEventRequest{
+		Event: letter,
+	}
+
+	// push the event
+	if _, err := x.EventServiceClient.PushEvent(ctx, args); err != nil {
+		return err
+	}
+
+	// done!
+	return nil
+}
@@ -0,0 +1,118 @@
+package eventv1sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1fake"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func TestDeadLetterErrorHandler(t *testing.T) {
+	client := &eventv1fake.FakeEventServiceClient{}
+
+	handler := &eventv1sdk.DeadLetterErrorHandler{
+		EventServiceClient: client,
+		Handler:            "orders",
+	}
+
+	event := newTestEvent(t)
+	original := proto.Clone(event)
+
+	ectx := eventv1.NewEventContext(event)
+	ectx.Attempt = 4
+
+	ctx := eventv1.NewContext(context.Background(), ectx)
+
+	if err := handler.HandleError(ctx, event, errors.New("cause")); err != nil {
+		t.Fatal(err)
+	}
+
+	if client.PushEventCallCount() != 1 {
+		t.Fatalf("got %d pushed events, want 1", client.PushEventCallCount())
+	}
+
+	_, args := client.PushEventArgsForCall(0)
+	letter := args.GetEvent()
+
+	if letter.GetId() != event.GetId() || letter.GetSource() != event.GetSource() || letter.GetSubject() != event.GetSubject() {
+		t.Errorf("got %v, want a copy of %v", letter, event)
+	}
+
+	if got, _ := eventv1.GetExtension[string](letter, eventv1sdk.DeadLetterErrorKey); got != "cause" {
+		t.Errorf("got error %q, want cause", got)
+	}
+
+	if got, _ := eventv1.GetExtension[string](letter, eventv1sdk.DeadLetterHandlerKey); got != "orders" {
+		t.Errorf("got handler %q, want orders", got)
+	}
+
+	if got, _ := eventv1.GetExtension[int](letter, eventv1sdk.DeadLetterAttemptKey); got != 4 {
+		t.Errorf("got attempt %d, want 4", got)
+	}
+
+	if got, err := eventv1.GetExtension[time.Time](letter, eventv1sdk.DeadLetterTimeKey); err != nil || time.Since(got) > time.Minute {
+		t.Errorf("got time %v, %v", got, err)
+	}
+
+	if !proto.Equal(event, original) {
+		t.Error("the original event is modified")
+	}
+}
+
+func TestDeadLetterErrorHandlerFailure(t *testing.T) {
+	client := &eventv1fake.FakeEventServiceClient{}
+	client.PushEventReturns(nil, errors.New("unavailable"))
+
+	handler := &eventv1sdk.DeadLetterErrorHandler{
+		EventServiceClient: client,
+	}
+
+	if err := handler.HandleError(context.Background(), newTestEvent(t), errors.New("cause")); err == nil {
+		t.Error("expected an error")
+	}
+
+	_, args := client.PushEventArgsForCall(0)
+
+	if got, _ := eventv1.GetExtension[int](args.GetEvent(), eventv1sdk.DeadLetterAttemptKey); got != 1 {
+		t.Errorf("got attempt %d, want 1", got)
+	}
+
+	if _, err := eventv1.GetExtension[string](args.GetEvent(), eventv1sdk.DeadLetterHandlerKey); !errors.Is(err, eventv1.ErrMissingExtension) {
+		t.Errorf("got %v, want no handler extension", err)
+	}
+}
+
+func TestEventServiceDeadLetter(t *testing.T) {
+	client := &eventv1fake.FakeEventServiceClient{}
+
+	service := &eventv1sdk.EventService{
+		EventHandler: eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+			return eventv1.Permanent(errors.New("cause"))
+		}),
+		ErrorHandler: &eventv1sdk.DeadLetterErrorHandler{EventServiceClient: client},
+	}
+
+	event := newTestEvent(t)
+
+	// the transport reports the delivery attempt
+	ectx := eventv1.NewEventContext(event)
+	ectx.Attempt = 5
+
+	ctx := eventv1.NewContext(context.Background(), ectx)
+
+	if _, err := service.PushEvent(ctx, &eventv1.PushEventRequest{Event: event}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, args := client.PushEventArgsForCall(0)
+
+	if got, _ := eventv1.GetExtension[int](args.GetEvent(), eventv1sdk.DeadLetterAttemptKey); got != 5 {
+		t.Errorf("got attempt %d, want 5", got)
+	}
+}
@@ -0,0 +1,502 @@
+package eventv1sdk
+
+import (
+	list "container/list"
+	context "context"
+	binary "encoding/binary"
+	errors "errors"
+	fmt "fmt"
+	sync "sync"
+	time "time"
+
+	connect "connectrpc.com/connect"
+	uuid "github.com/google/uuid"
+	slogr "github.com/ralch/slogr"
+	bbolt "go.etcd.io/bbolt"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+// DedupStatus represents the status of an event in a DedupStore.
+type DedupStatus int
+
+const (
+	// DedupAcquired means that the caller holds the lease of the event.
+	DedupAcquired DedupStatus = iota
+	// DedupInProgress means that another caller holds the lease of the event.
+	DedupInProgress
+	// DedupCompleted means that the event has been handled.
+	DedupCompleted
+)
+
+// DedupStore is the interface that stores the status of the handled events.
+// A lease is owned by the caller that acquired it and is identified by the
+// token that Acquire returns.
+type DedupStore interface {
+	// Acquire acquires the lease of the given key for the given duration
+	// unless the key is leased or completed. The token of the lease is
+	// returned with the DedupAcquired status.
+	Acquire(ctx context.Context, key string, lease time.Duration) (DedupStatus, string, error)
+	// Renew extends the lease of the given key by the given duration. It
+	// returns ErrLeaseLost if the token does not own the lease anymore.
+	Renew(ctx context.Context, key, token string, lease time.Duration) error
+	// Commit marks the given key as completed. It returns ErrLeaseLost if
+	// another caller owns the lease.
+	Commit(ctx context.Context, key, token string) error
+	// Release releases the lease of the given key if the token owns it.
+	Release(ctx context.Context, key, token string) error
+}
+
+// DedupConfig represents a configuration for the deduplication middleware.
+type DedupConfig struct {
+	// Store contains the status of the handled events.
+	Store DedupStore
+	// Lease is the time for which an event is reserved while the handler
+	// handles it. The lease is renewed every half of it until the handler
+	// returns. A Lease that is not positive defaults to one minute.
+	Lease time.Duration
+}
+
+var (
+	// ErrEventInProgress is returned by the deduplication middleware when another
+	// delivery of the same event is being handled.
+	ErrEventInProgress = fmt.Errorf("event in progress")
+	// ErrLeaseLost is returned by a DedupStore when the lease of an event is
+	// owned by another caller.
+	ErrLeaseLost = fmt.Errorf("lease lost")
+)
+
+// WithDedup skips the events that have been handled already. The events are
+// identified by their source and id. The middleware leases the event before it
+// calls the handler, so a concurrent delivery of the same event fails with a
+// retryable ErrEventInProgress and connect.CodeAborted. The lease is renewed
+// while the handler runs. The event is marked as completed only when the
+// handler succeeds, otherwise the lease is released.
+func WithDedup(config *DedupConfig) eventv1.Middleware {
+	lease := config.Lease
+	if lease <= 0 {
+		lease = time.Minute
+	}
+
+	innerFn := func(next eventv1.EventHandler) eventv1.EventHandler {
+		fn := func(ctx context.Context, event *eventv1.Event) error {
+			key := event.GetSource() + "\n" + event.GetId()
+
+			status, token, err := config.Store.Acquire(ctx, key, lease)
+			if err != nil {
+				return eventv1.Retryable(connect.NewError(connect.CodeUnavailable, err), 0)
+			}
+
+			logger := slogr.FromContext(ctx)
+
+			switch status {
+			case DedupCompleted:
+				logger.InfoContext(ctx, "skip a duplicate event")
+				// done!
+				return nil
+			case DedupInProgress:
+				return eventv1.Retryable(connect.NewError(connect.CodeAborted, ErrEventInProgress), lease)
+			}
+
+			// renew the lease while the handler runs
+			stop := renewDedupLease(ctx, config.Store, key, token, lease)
+
+			err = next.HandleEvent(ctx, event)
+			// stop the renewal
+			stop()
+
+			if err != nil {
+				if rerr := config.Store.Release(context.WithoutCancel(ctx), key, token); rerr != nil {
+					logger.ErrorContext(ctx, "cannot release the event lease", slogr.Error(rerr))
+				}
+
+				return err
+			}
+
+			if err := config.Store.Commit(context.WithoutCancel(ctx), key, token); err != nil {
+				if errors.Is(err, ErrLeaseLost) {
+					// the event is handled and the new owner of the lease handles it again
+					logger.WarnContext(ctx, "cannot commit the event", slogr.Error(err))
+					return nil
+				}
+
+				return err
+			}
+
+			return nil
+		}
+
+		return eventv1.EventHandlerFunc(fn)
+	}
+
+	return innerFn
+}
+
+// renewDedupLease renews the lease every half of its duration until the
+// returned function is called.
+func renewDedupLease(ctx context.Context, store DedupStore, key, token string, lease time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		// a lease of one nanosecond has no half
+		ticker := time.NewTicker(max(lease/2, time.Nanosecond))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := store.Renew(ctx, key, token, lease); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				logger := slogr.FromContext(ctx)
+				logger.ErrorContext(ctx, "cannot renew the event lease", slogr.Error(err))
+
+				if errors.Is(err, ErrLeaseLost) {
+					return
+				}
+			}
+		}
+	}()
+
+	stop := func() {
+		cancel()
+		<-done
+	}
+
+	return stop
+}
+
+var _ DedupStore = &MemoryDedupStore{}
+
+// MemoryDedupStore is an in-memory DedupStore. It keeps the completed events
+// for a fixed time and evicts the least recently used entries when it is full.
+// The leases in progress are never evicted, so the store may exceed its
+// capacity while they are held.
+type MemoryDedupStore struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type dedupEntry struct {
+	key       string
+	token     string
+	completed bool
+	expires   time.Time
+}
+
+// NewMemoryDedupStore creates a new MemoryDedupStore that keeps at most
+// capacity entries and forgets the completed events after ttl.
+func NewMemoryDedupStore(capacity int, ttl time.Duration) *MemoryDedupStore {
+	return &MemoryDedupStore{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Acquire implements DedupStore.
+func (x *MemoryDedupStore) Acquire(_ context.Context, key string, lease time.Duration) (DedupStatus, string, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	now := time.Now()
+
+	if element, ok := x.entries[key]; ok {
+		entry := element.Value.(*dedupEntry)
+
+		if now.Before(entry.expires) {
+			x.order.MoveToFront(element)
+
+			if entry.completed {
+				return DedupCompleted, "", nil
+			}
+
+			return DedupInProgress, "", nil
+		}
+
+		x.order.Remove(element)
+		delete(x.entries, key)
+	}
+
+	token := uuid.NewString()
+
+	x.entries[key] = x.order.PushFront(&dedupEntry{
+		key:     key,
+		token:   token,
+		expires: now.Add(lease),
+	})
+
+	x.evict(now)
+
+	return DedupAcquired, token, nil
+}
+
+// evict evicts the least recently used entries that are not in progress until
+// the store fits its capacity.
+func (x *MemoryDedupStore) evict(now time.Time) {
+	for element := x.order.Back(); element != nil && x.capacity > 0 && x.order.Len() > x.capacity; {
+		prev := element.Prev()
+
+		if entry := element.Value.(*dedupEntry); entry.completed || !now.Before(entry.expires) {
+			x.order.Remove(element)
+			delete(x.entries, entry.key)
+		}
+
+		element = prev
+	}
+}
+
+// Renew implements DedupStore.
+func (x *MemoryDedupStore) Renew(_ context.Context, key, token string, lease time.Duration) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	element, ok := x.entries[key]
+	if !ok {
+		return ErrLeaseLost
+	}
+
+	entry := element.Value.(*dedupEntry)
+	if entry.completed || entry.token != token {
+		return ErrLeaseLost
+	}
+
+	entry.expires = time.Now().Add(lease)
+	return nil
+}
+
+// Commit implements DedupStore.
+func (x *MemoryDedupStore) Commit(_ context.Context, key, token string) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	now := time.Now()
+
+	entry := &dedupEntry{
+		key:       key,
+		completed: true,
+		expires:   now.Add(x.ttl),
+	}
+
+	element, ok := x.entries[key]
+	if !ok {
+		x.entries[key] = x.order.PushFront(entry)
+		x.evict(now)
+		return nil
+	}
+
+	current := element.Value.(*dedupEntry)
+
+	switch {
+	case current.completed:
+		return nil
+	case current.token != token && now.Before(current.expires):
+		return ErrLeaseLost
+	}
+
+	element.Value = entry
+	x.order.MoveToFront(element)
+
+	return nil
+}
+
+// Release implements DedupStore.
+func (x *MemoryDedupStore) Release(_ context.Context, key, token string) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if element, ok := x.entries[key]; ok {
+		if entry := element.Value.(*dedupEntry); !entry.completed && entry.token == token {
+			x.order.Remove(element)
+			delete(x.entries, key)
+		}
+	}
+
+	return nil
+}
+
+var _ DedupStore = &FileDedupStore{}
+
+var dedupBucket = []byte("events")
+
+// FileDedupStore is a DedupStore backed by an embedded key-value file. It
+// keeps the completed events for a fixed time.
+type FileDedupStore struct {
+	db  *bbolt.DB
+	ttl time.Duration
+}
+
+// NewFileDedupStore opens the FileDedupStore at the given path. It forgets the
+// completed events after ttl.
+func NewFileDedupStore(path string, ttl time.Duration) (*FileDedupStore, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(dedupBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	store := &FileDedupStore{
+		db:  db,
+		ttl: ttl,
+	}
+
+	return store, nil
+}
+
+// Close closes the store.
+func (x *FileDedupStore) Close() error {
+	return x.db.Close()
+}
+
+// Acquire implements DedupStore.
+func (x *FileDedupStore) Acquire(_ context.Context, key string, lease time.Duration) (DedupStatus, string, error) {
+	status := DedupAcquired
+	token := uuid.NewString()
+
+	err := x.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(dedupBucket)
+		now := time.Now()
+
+		if entry, ok := decodeDedupEntry(bucket.Get([]byte(key))); ok && now.Before(entry.expires) {
+			if entry.completed {
+				status = DedupCompleted
+			} else {
+				status = DedupInProgress
+			}
+
+			return nil
+		}
+
+		return bucket.Put([]byte(key), encodeDedupEntry(&dedupEntry{token: token, expires: now.Add(lease)}))
+	})
+
+	if err != nil || status != DedupAcquired {
+		token = ""
+	}
+
+	return status, token, err
+}
+
+// Renew implements DedupStore.
+func (x *FileDedupStore) Renew(_ context.Context, key, token string, lease time.Duration) error {
+	return x.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(dedupBucket)
+
+		entry, ok := decodeDedupEntry(bucket.Get([]byte(key)))
+		if !ok || entry.completed || entry.token != token {
+			return ErrLeaseLost
+		}
+
+		entry.expires = time.Now().Add(lease)
+		return bucket.Put([]byte(key), encodeDedupEntry(entry))
+	})
+}
+
+// Commit implements DedupStore.
+func (x *FileDedupStore) Commit(_ context.Context, key, token string) error {
+	return x.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(dedupBucket)
+		now := time.Now()
+
+		if entry, ok := decodeDedupEntry(bucket.Get([]byte(key))); ok {
+			switch {
+			case entry.completed:
+				return nil
+			case entry.token != token && now.Before(entry.expires):
+				return ErrLeaseLost
+			}
+		}
+
+		return bucket.Put([]byte(key), encodeDedupEntry(&dedupEntry{completed: true, expires: now.Add(x.ttl)}))
+	})
+}
+
+// Release implements DedupStore.
+func (x *FileDedupStore) Release(_ context.Context, key, token string) error {
+	return x.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(dedupBucket)
+
+		if entry, ok := decodeDedupEntry(bucket.Get([]byte(key))); ok && !entry.completed && entry.token == token {
+			return bucket.Delete([]byte(key))
+		}
+
+		return nil
+	})
+}
+
+// Purge deletes the expired entries. It should be called periodically to
+// bound the size of the file.
+func (x *FileDedupStore) Purge(_ context.Context) error {
+	return x.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(dedupBucket)
+		now := time.Now()
+
+		var keys [][]byte
+		// collect the expired keys
+		err := bucket.ForEach(func(key, value []byte) error {
+			if entry, ok := decodeDedupEntry(value); !ok || !now.Before(entry.expires) {
+				keys = append(keys, append([]byte(nil), key...))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// encodeDedupEntry encodes the entry as the completed flag, the expiration
+// time and the token of the lease.
+func encodeDedupEntry(entry *dedupEntry) []byte {
+	value := make([]byte, 9, 9+len(entry.token))
+	if entry.completed {
+		value[0] = 1
+	}
+
+	binary.BigEndian.PutUint64(value[1:], uint64(entry.expires.UnixNano()))
+	return append(value, entry.token...)
+}
+
+func decodeDedupEntry(value []byte) (*dedupEntry, bool) {
+	if len(value) < 9 {
+		return nil, false
+	}
+
+	entry := &dedupEntry{
+		completed: value[0] == 1,
+		expires:   time.Unix(0, int64(binary.BigEndian.Uint64(value[1:9]))),
+		token:     string(value[9:]),
+	}
+
+	return entry, true
+}
@@ -0,0 +1,270 @@
+package eventv1sdk_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func newTestDedupStores(t *testing.T) map[string]eventv1sdk.DedupStore {
+	t.Helper()
+
+	file, err := eventv1sdk.NewFileDedupStore(filepath.Join(t.TempDir(), "dedup.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { file.Close() })
+
+	return map[string]eventv1sdk.DedupStore{
+		"memory": eventv1sdk.NewMemoryDedupStore(100, time.Hour),
+		"file":   file,
+	}
+}
+
+func TestDedupStore(t *testing.T) {
+	for name, store := range newTestDedupStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			status, token, err := store.Acquire(ctx, "a", time.Minute)
+			if err != nil || status != eventv1sdk.DedupAcquired || token == "" {
+				t.Fatalf("got %v, %q, %v, want an acquired lease", status, token, err)
+			}
+
+			if status, other, _ := store.Acquire(ctx, "a", time.Minute); status != eventv1sdk.DedupInProgress || other != "" {
+				t.Errorf("got %v, %q, want %v", status, other, eventv1sdk.DedupInProgress)
+			}
+
+			if err := store.Renew(ctx, "a", token, time.Minute); err != nil {
+				t.Errorf("unexpected renew error: %v", err)
+			}
+
+			if err := store.Commit(ctx, "a", token); err != nil {
+				t.Fatal(err)
+			}
+
+			if status, _, _ := store.Acquire(ctx, "a", time.Minute); status != eventv1sdk.DedupCompleted {
+				t.Errorf("got %v, want %v", status, eventv1sdk.DedupCompleted)
+			}
+
+			if err := store.Renew(ctx, "a", token, time.Minute); !errors.Is(err, eventv1sdk.ErrLeaseLost) {
+				t.Errorf("got %v, want %v", err, eventv1sdk.ErrLeaseLost)
+			}
+		})
+	}
+}
+
+func TestDedupStoreLeaseOwner(t *testing.T) {
+	for name, store := range newTestDedupStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// the first lease expires before its owner is done
+			_, first, _ := store.Acquire(ctx, "a", time.Millisecond)
+			time.Sleep(5 * time.Millisecond)
+
+			status, second, _ := store.Acquire(ctx, "a", time.Minute)
+			if status != eventv1sdk.DedupAcquired {
+				t.Fatalf("got %v, want %v", status, eventv1sdk.DedupAcquired)
+			}
+
+			if err := store.Renew(ctx, "a", first, time.Minute); !errors.Is(err, eventv1sdk.ErrLeaseLost) {
+				t.Errorf("got renew %v, want %v", err, eventv1sdk.ErrLeaseLost)
+			}
+
+			if err := store.Commit(ctx, "a", first); !errors.Is(err, eventv1sdk.ErrLeaseLost) {
+				t.Errorf("got commit %v, want %v", err, eventv1sdk.ErrLeaseLost)
+			}
+
+			// the stale owner does not release the lease of the new owner
+			if err := store.Release(ctx, "a", first); err != nil {
+				t.Fatal(err)
+			}
+
+			if status, _, _ := store.Acquire(ctx, "a", time.Minute); status != eventv1sdk.DedupInProgress {
+				t.Errorf("got %v, want %v", status, eventv1sdk.DedupInProgress)
+			}
+
+			if err := store.Release(ctx, "a", second); err != nil {
+				t.Fatal(err)
+			}
+
+			if status, _, _ := store.Acquire(ctx, "a", time.Minute); status != eventv1sdk.DedupAcquired {
+				t.Errorf("got %v, want %v", status, eventv1sdk.DedupAcquired)
+			}
+		})
+	}
+}
+
+func TestMemoryDedupStoreEviction(t *testing.T) {
+	ctx := context.Background()
+	store := eventv1sdk.NewMemoryDedupStore(2, time.Hour)
+
+	_, token, _ := store.Acquire(ctx, "a", time.Minute)
+	_, _, _ = store.Acquire(ctx, "b", time.Minute)
+	_, _, _ = store.Acquire(ctx, "c", time.Minute)
+
+	// the leases in progress are not evicted
+	if status, _, _ := store.Acquire(ctx, "a", time.Minute); status != eventv1sdk.DedupInProgress {
+		t.Errorf("got %v, want %v", status, eventv1sdk.DedupInProgress)
+	}
+
+	if err := store.Commit(ctx, "a", token); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, _ = store.Acquire(ctx, "d", time.Minute)
+
+	// the completed entry is evicted to make room
+	if status, _, _ := store.Acquire(ctx, "a", time.Minute); status != eventv1sdk.DedupAcquired {
+		t.Errorf("got %v, want %v", status, eventv1sdk.DedupAcquired)
+	}
+}
+
+func TestFileDedupStorePurge(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := eventv1sdk.NewFileDedupStore(filepath.Join(t.TempDir(), "dedup.db"), time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	_, token, _ := store.Acquire(ctx, "a", time.Minute)
+	if err := store.Commit(ctx, "a", token); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if err := store.Purge(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if status, _, _ := store.Acquire(ctx, "a", time.Minute); status != eventv1sdk.DedupAcquired {
+		t.Errorf("got %v, want %v", status, eventv1sdk.DedupAcquired)
+	}
+}
+
+func TestWithDedup(t *testing.T) {
+	var calls atomic.Int32
+
+	fail := true
+
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+		calls.Add(1)
+		if fail {
+			return errors.New("failure")
+		}
+
+		return nil
+	}), eventv1sdk.WithDedup(&eventv1sdk.DedupConfig{
+		Store: eventv1sdk.NewMemoryDedupStore(100, time.Hour),
+	}))
+
+	event := newTestEvent(t)
+	ctx := context.Background()
+
+	// a failure releases the lease
+	if err := handler.HandleEvent(ctx, event); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	fail = false
+
+	for range 3 {
+		if err := handler.HandleEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("got %d calls, want 2", got)
+	}
+}
+
+func TestWithDedupLease(t *testing.T) {
+	cases := []struct {
+		name  string
+		lease time.Duration
+	}{
+		{"negative", -time.Second},
+		{"one nanosecond", time.Nanosecond},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			var calls atomic.Int32
+
+			handler := eventv1.Chain(eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+				calls.Add(1)
+				// let the lease be renewed
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			}), eventv1sdk.WithDedup(&eventv1sdk.DedupConfig{
+				Store: eventv1sdk.NewMemoryDedupStore(100, time.Hour),
+				Lease: item.lease,
+			}))
+
+			event := newTestEvent(t)
+
+			for range 2 {
+				if err := handler.HandleEvent(context.Background(), event); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := calls.Load(); got != 1 {
+				t.Errorf("got %d calls, want 1", got)
+			}
+		})
+	}
+}
+
+func TestWithDedupInProgress(t *testing.T) {
+	started := make(chan struct{})
+	finish := make(chan struct{})
+
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+		close(started)
+		<-finish
+		return nil
+	}), eventv1sdk.WithDedup(&eventv1sdk.DedupConfig{
+		Store: eventv1sdk.NewMemoryDedupStore(100, time.Hour),
+		Lease: 20 * time.Millisecond,
+	}))
+
+	event := newTestEvent(t)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- handler.HandleEvent(context.Background(), event)
+	}()
+
+	<-started
+	// the lease is renewed while the handler runs
+	time.Sleep(60 * time.Millisecond)
+
+	err := handler.HandleEvent(context.Background(), event)
+	if !errors.Is(err, eventv1sdk.ErrEventInProgress) || connect.CodeOf(err) != connect.CodeAborted {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrEventInProgress)
+	}
+
+	if kind := eventv1.KindOf(err); kind != eventv1.ErrorKindRetryable {
+		t.Errorf("got kind %v, want %v", kind, eventv1.ErrorKindRetryable)
+	}
+
+	close(finish)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,14 +2,21 @@ package eventv1sdk
 
 import (
 	context "context"
+	errors "errors"
+	fmt "fmt"
+	slog "log/slog"
+	math "math"
 	http "net/http"
-	time "time"
+	strconv "strconv"
 
 	connect "connectrpc.com/connect"
 	interceptor "github.com/connect-sdk/interceptor"
 	middleware "github.com/connect-sdk/middleware"
 	chi "github.com/go-chi/chi/v5"
-	metadata "google.golang.org/grpc/metadata"
+	slogr "github.com/ralch/slogr"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 
 	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
 	eventv1connect "github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1connect"
@@ -17,12 +24,24 @@ import (
 
 var _ eventv1.EventServiceClient = &EventServiceClient{}
 
+var _ eventv1.AsyncEventServiceClient = &EventServiceClient{}
+
+// DefaultMaxPendingEvents is the number of events that an EventServiceClient
+// pushes in the background at the same time.
+const DefaultMaxPendingEvents = 100
+
+// ErrTooManyPendingEvents is returned by EventServiceClient.PushEventAsync when
+// DefaultMaxPendingEvents events are pushed in the background.
+var ErrTooManyPendingEvents = fmt.Errorf("too many pending events")
+
 // EventServiceClient is a client for the cloud.event.v1.EventService service.
 type EventServiceClient struct {
-	client eventv1connect.EventServiceClient
+	client  eventv1connect.EventServiceClient
+	pending chan struct{}
 }
 
 // NewEventServiceClient creates a new cloud.event.v1.EventServiceClient client.
+// The client implements eventv1.AsyncEventServiceClient as well.
 func NewEventServiceClient(uri string, options ...connect.ClientOption) eventv1.EventServiceClient {
 	// prepare the options
 	options = append(options, interceptor.WithContext())
@@ -30,7 +49,8 @@ func NewEventServiceClient(uri string, options ...connect.ClientOption) eventv1.
 	options = append(options, interceptor.WithLogger())
 	// prepare the clinet
 	client := &EventServiceClient{
-		client: eventv1connect.NewEventServiceClient(http.DefaultClient, uri, options...),
+		client:  eventv1connect.NewEventServiceClient(http.DefaultClient, uri, options...),
+		pending: make(chan struct{}, DefaultMaxPendingEvents),
 	}
 
 	return client
@@ -46,12 +66,52 @@ func (x *EventServiceClient) PushEvent(ctx context.Context, r *eventv1.PushEvent
 	return response.Msg, nil
 }
 
+// PushEventAsync pushes a given event to cloud.event.v1.EventService service
+// in the background. At most DefaultMaxPendingEvents events are pushed at the
+// same time. When the limit is reached the call does not block; it returns a
+// result that fails with ErrTooManyPendingEvents and
+// connect.CodeResourceExhausted, so the caller may push the event again later.
+func (x *EventServiceClient) PushEventAsync(ctx context.Context, r *eventv1.PushEventRequest) *eventv1.PushResult {
+	var (
+		response *eventv1.PushEventResponse
+		err      error
+	)
+
+	ready := make(chan struct{})
+
+	select {
+	case x.pending <- struct{}{}:
+	default:
+		err = connect.NewError(connect.CodeResourceExhausted, ErrTooManyPendingEvents)
+		close(ready)
+		// done!
+		return eventv1.NewPushResult(ready, func() (string, *eventv1.PushEventResponse, error) {
+			return "", nil, err
+		})
+	}
+
+	// push the event
+	go func() {
+		defer func() { <-x.pending }()
+		defer close(ready)
+		response, err = x.PushEvent(ctx, r)
+	}()
+
+	resolve := func() (string, *eventv1.PushEventResponse, error) {
+		return response.GetMessageId(), response, err
+	}
+
+	return eventv1.NewPushResult(ready, resolve)
+}
+
 var _ eventv1connect.EventServiceHandler = &EventServiceHandler{}
 
 // EventServiceHandler represents an instance of cloud.event.v1.EventServiceHandler handler.
 type EventServiceHandler struct {
 	// EventService contains an instance of cloud.event.v1.EventService service.
 	EventService eventv1.EventService
+	// Webhook enables the CloudEvents HTTP Webhook validation handshake.
+	Webhook *WebhookConfig
 }
 
 // Mount mounts the controller to a given router.
@@ -62,11 +122,15 @@ func (x *EventServiceHandler) Mount(r chi.Router) {
 	options = append(options, interceptor.WithTracer())
 	options = append(options, interceptor.WithLogger())
 	options = append(options, interceptor.WithRecovery())
-	options = append(options, interceptor.WithValidator())
+	options = append(options, WithSpecValidator())
 
 	r.Group(func(r chi.Router) {
 		// mount the middleware
 		r.Use(middleware.WithLogger())
+		// mount the webhook handshake
+		if x.Webhook != nil {
+			r.Use(WithWebhook(x.Webhook))
+		}
 		// create the handler
 		path, handler := eventv1connect.NewEventServiceHandler(x, options...)
 		// mount the handler
@@ -74,47 +138,196 @@ func (x *EventServiceHandler) Mount(r chi.Router) {
 	})
 }
 
-// PushEvent pushes a given event to connect.runtime.v1.EventService service.
+// PushEvent pushes a given event to connect.runtime.v1.EventService service. The
+// response carries the receipt of the event. The event id and the accepted
+// time are set when the EventService does not set them.
 func (x *EventServiceHandler) PushEvent(ctx context.Context, r *connect.Request[eventv1.PushEventRequest]) (*connect.Response[eventv1.PushEventResponse], error) {
 	response, err := x.EventService.PushEvent(ctx, r.Msg)
 	if err != nil {
 		return nil, err
 	}
 
+	if response == nil {
+		response = &eventv1.PushEventResponse{}
+	}
+
+	// prepare the receipt
+	if response.EventId == "" {
+		response.EventId = r.Msg.GetEvent().GetId()
+	}
+
+	if response.AcceptTime == nil {
+		response.AcceptTime = timestamppb.Now()
+	}
+
 	return connect.NewResponse(response), nil
 }
 
+// WithSpecValidator set up the request validator that checks the events against
+// the CloudEvents specification with PushEventRequest.ValidateSpec.
+func WithSpecValidator() connect.Option {
+	type RequestWithValidation interface {
+		ValidateSpec() error
+	}
+
+	interFn := func(next connect.UnaryFunc) connect.UnaryFunc {
+		// prepare the callback
+		fn := func(ctx context.Context, request connect.AnyRequest) (connect.AnyResponse, error) {
+			if value, ok := request.Any().(RequestWithValidation); ok {
+				if err := value.ValidateSpec(); err != nil {
+					return nil, newSpecValidationError(err)
+				}
+			}
+			// execute the method
+			return next(ctx, request)
+		}
+
+		return fn
+	}
+
+	unaryFn := connect.UnaryInterceptorFunc(interFn)
+	// prepare the option
+	return connect.WithInterceptors(unaryFn)
+}
+
+// newSpecValidationError returns a connect.CodeInvalidArgument error with the
+// field violations of the given validation error.
+func newSpecValidationError(err error) error {
+	type Error interface {
+		Field() string
+		Reason() string
+	}
+
+	type ErrorCollection interface {
+		AllErrors() []error
+	}
+
+	xerr, ok := err.(ErrorCollection)
+	if !ok {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	verr := &errdetails.BadRequest{}
+
+	for _, item := range xerr.AllErrors() {
+		if ferr, ok := item.(Error); ok {
+			verr.FieldViolations = append(verr.FieldViolations,
+				&errdetails.BadRequest_FieldViolation{
+					Field:       ferr.Field(),
+					Description: ferr.Reason(),
+				},
+			)
+		}
+	}
+
+	cerr := connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("bad request"))
+
+	if detail, derr := connect.NewErrorDetail(verr); derr == nil {
+		cerr.AddDetail(detail)
+	}
+
+	return cerr
+}
+
 var _ eventv1.EventService = &EventService{}
 
-// EventService represents a handler of cloud.event.v1.EventService service.
+// EventService represents a handler of cloud.event.v1.EventService service. It
+// maps the errors of the handler to the delivery outcome. The skipped events
+// and the events that fail permanently are acknowledged with a successful
+// response, so Pub/Sub does not deliver them again. The retryable failures are
+// returned as connect.CodeUnavailable errors with the suggested retry delay,
+// so Pub/Sub delivers them again. Any other error is returned as it is.
 type EventService struct {
 	// EventService contains an instance of cloud.event.v1.EventHandler handler.
 	EventHandler eventv1.EventHandler
+	// ErrorHandler receives the events that fail permanently. It is optional.
+	// The event is delivered again when the ErrorHandler fails. Use
+	// DeadLetterErrorHandler to forward the events to a dead-letter service.
+	ErrorHandler eventv1.ErrorHandler
 }
 
 // PushEvent implements eventv1.EventService.
 func (x *EventService) PushEvent(ctx context.Context, r *eventv1.PushEventRequest) (*eventv1.PushEventResponse, error) {
-	meta, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		meta = metadata.Pairs()
+	ectx := eventv1.NewEventContext(r.Event)
+	// keep the attempt reported by the transport
+	if prev, ok := eventv1.FromContext(ctx); ok && prev.ID == ectx.ID {
+		ectx.Attempt = prev.Attempt
 	}
 
 	// add the event attributes to the context
-	meta.Append("ce-id", r.Event.GetId())
-	meta.Append("ce-type", r.Event.GetType())
-	meta.Append("ce-source", r.Event.GetSource())
-	meta.Append("ce-subject", r.Event.GetSubject())
-	meta.Append("ce-dataschema", r.Event.GetDataSchema())
-	meta.Append("ce-specversion", r.Event.GetSpecVersion())
-	meta.Append("ce-datacontenttype", r.Event.GetDataContentType())
-	meta.Append("ce-time", r.Event.GetTime().Format(time.RFC3339))
-	// override the context
-	ctx = metadata.NewOutgoingContext(ctx, meta)
+	ctx = eventv1.NewContext(ctx, ectx)
 
 	// push the event
 	if err := x.EventHandler.HandleEvent(ctx, r.Event); err != nil {
-		return nil, err
+		if err := x.handleError(ctx, r.Event, err); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &eventv1.PushEventResponse{
+		EventId:    r.Event.GetId(),
+		AcceptTime: timestamppb.Now(),
+	}
+	// done!
+	return response, nil
+}
+
+func (x *EventService) handleError(ctx context.Context, event *eventv1.Event, err error) error {
+	return handleEventError(ctx, x.ErrorHandler, event, err)
+}
+
+// handleEventError maps the error of the given event to the delivery outcome
+// as described by EventService. The events that fail permanently are passed to
+// the optional error handler.
+func handleEventError(ctx context.Context, handler eventv1.ErrorHandler, event *eventv1.Event, err error) error {
+	logger := slogr.FromContext(ctx)
+
+	switch eventv1.KindOf(err) {
+	case eventv1.ErrorKindSkip:
+		logger.InfoContext(ctx, "skip an event", slog.String("reason", err.Error()))
+		// done!
+		return nil
+	case eventv1.ErrorKindPermanent:
+		logger.ErrorContext(ctx, "cannot handle an event", slogr.Error(err))
+
+		if handler != nil {
+			if ferr := handler.HandleError(ctx, event, err); ferr != nil {
+				return newRetryableError(fmt.Errorf("cannot forward the event: %w", ferr))
+			}
+		}
+		// done!
+		return nil
+	case eventv1.ErrorKindRetryable:
+		return newRetryableError(err)
+	default:
+		return err
+	}
+}
+
+// newRetryableError returns a connect.CodeUnavailable error with the suggested
+// retry delay of the given error in the Retry-After header and in a RetryInfo
+// detail. The code of a connect.Error is preserved.
+func newRetryableError(err error) error {
+	code := connect.CodeUnavailable
+
+	var xerr *connect.Error
+	if errors.As(err, &xerr) && xerr.Code() != connect.CodeUnknown {
+		code = xerr.Code()
+	}
+
+	cerr := connect.NewError(code, err)
+
+	if after, ok := eventv1.RetryAfterOf(err); ok {
+		cerr.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(after.Seconds()))))
+
+		info := &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(after),
+		}
+
+		if detail, derr := connect.NewErrorDetail(info); derr == nil {
+			cerr.AddDetail(detail)
+		}
 	}
 
-	return &eventv1.PushEventResponse{}, nil
+	return cerr
 }
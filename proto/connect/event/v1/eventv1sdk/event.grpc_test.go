@@ -0,0 +1,367 @@
+package eventv1sdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	chi "github.com/go-chi/chi/v5"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1fake"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func newTestEventServiceServer(t *testing.T, service eventv1.EventService) eventv1.EventServiceClient {
+	t.Helper()
+
+	handler := &eventv1sdk.EventServiceHandler{
+		EventService: service,
+	}
+
+	router := chi.NewRouter()
+	handler.Mount(router)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return eventv1sdk.NewEventServiceClient(server.URL)
+}
+
+func TestEventServiceHandlerValidation(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*eventv1.Event)
+		code   connect.Code
+	}{
+		{"without data", func(event *eventv1.Event) { event.Data = nil }, 0},
+		{"long attribute name", func(event *eventv1.Event) { _ = event.SetExtension("averyveryverylongextensionname", "a") }, 0},
+		{"media type without subtype", func(event *eventv1.Event) { event.SetDataContentType("json") }, connect.CodeInvalidArgument},
+		{"missing type", func(event *eventv1.Event) { event.SetType("") }, connect.CodeInvalidArgument},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			service := &eventv1fake.FakeEventService{}
+			client := newTestEventServiceServer(t, service)
+
+			event := newTestEvent(t)
+			item.modify(event)
+
+			_, err := client.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: event})
+
+			switch {
+			case item.code == 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case item.code != 0 && connect.CodeOf(err) != item.code:
+				t.Errorf("got %v, want %v", err, item.code)
+			}
+
+			want := 0
+			if item.code == 0 {
+				want = 1
+			}
+
+			if got := service.PushEventCallCount(); got != want {
+				t.Errorf("got %d pushed events, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestEventServiceContext(t *testing.T) {
+	event := newTestEvent(t)
+
+	var got *eventv1.EventContext
+
+	service := &eventv1sdk.EventService{
+		EventHandler: eventv1.EventHandlerFunc(func(ctx context.Context, _ *eventv1.Event) error {
+			got, _ = eventv1.FromContext(ctx)
+			return nil
+		}),
+	}
+
+	if _, err := service.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: event}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got == nil {
+		t.Fatal("missing event context")
+	}
+
+	if got.ID != event.GetId() || got.Type != event.GetType() || got.Subject != event.GetSubject() {
+		t.Errorf("got %+v, want the attributes of %v", got, event)
+	}
+}
+
+func TestEventServiceErrors(t *testing.T) {
+	cause := errors.New("cause")
+
+	cases := []struct {
+		name      string
+		err       error
+		code      connect.Code
+		forwarded bool
+	}{
+		{"skip", eventv1.Skip(cause), 0, false},
+		{"permanent", eventv1.Permanent(cause), 0, true},
+		{"data error", &eventv1.DataError{Err: cause}, 0, true},
+		{"retryable", eventv1.Retryable(cause, 1500*time.Millisecond), connect.CodeUnavailable, false},
+		{"retryable with code", eventv1.Retryable(connect.NewError(connect.CodeResourceExhausted, cause), 0), connect.CodeResourceExhausted, false},
+		{"unknown", connect.NewError(connect.CodeInternal, cause), connect.CodeInternal, false},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			errorHandler := &eventv1fake.FakeErrorHandler{}
+			eventHandler := &eventv1fake.FakeEventHandler{}
+			eventHandler.HandleEventReturns(item.err)
+
+			service := &eventv1sdk.EventService{
+				EventHandler: eventHandler,
+				ErrorHandler: errorHandler,
+			}
+
+			_, err := service.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: newTestEvent(t)})
+
+			switch {
+			case item.code == 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case item.code != 0 && connect.CodeOf(err) != item.code:
+				t.Errorf("got %v, want %v", err, item.code)
+			}
+
+			if got := errorHandler.HandleErrorCallCount() == 1; got != item.forwarded {
+				t.Errorf("got forwarded %v, want %v", got, item.forwarded)
+			}
+		})
+	}
+}
+
+func TestEventServiceRetryInfo(t *testing.T) {
+	service := &eventv1sdk.EventService{
+		EventHandler: eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+			return eventv1.Retryable(errors.New("cause"), 1500*time.Millisecond)
+		}),
+	}
+
+	_, err := service.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: newTestEvent(t)})
+
+	var xerr *connect.Error
+	if !errors.As(err, &xerr) {
+		t.Fatalf("got %v, want a connect error", err)
+	}
+
+	if got := xerr.Meta().Get("Retry-After"); got != "2" {
+		t.Errorf("got Retry-After %q, want 2", got)
+	}
+
+	var found bool
+	for _, detail := range xerr.Details() {
+		value, derr := detail.Value()
+		if info, ok := value.(*errdetails.RetryInfo); derr == nil && ok {
+			found = info.GetRetryDelay().AsDuration() == 1500*time.Millisecond
+		}
+	}
+
+	if !found {
+		t.Error("missing the RetryInfo detail")
+	}
+}
+
+func TestEventServiceErrorHandlerFailure(t *testing.T) {
+	errorHandler := &eventv1fake.FakeErrorHandler{}
+	errorHandler.HandleErrorReturns(errors.New("unavailable"))
+
+	service := &eventv1sdk.EventService{
+		EventHandler: eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+			return eventv1.Permanent(errors.New("cause"))
+		}),
+		ErrorHandler: errorHandler,
+	}
+
+	_, err := service.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: newTestEvent(t)})
+	if connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Errorf("got %v, want %v", err, connect.CodeUnavailable)
+	}
+}
+
+func TestEventServiceClientPushEventAsync(t *testing.T) {
+	service := &eventv1fake.FakeEventService{}
+	service.PushEventReturns(&eventv1.PushEventResponse{MessageId: "message-1"}, nil)
+
+	client := newTestEventServiceServer(t, service).(eventv1.AsyncEventServiceClient)
+
+	event := newTestEvent(t)
+	result := client.PushEventAsync(context.Background(), &eventv1.PushEventRequest{Event: event})
+
+	response, err := result.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := response.GetEventId(); got != event.GetId() {
+		t.Errorf("got event id %q, want %q", got, event.GetId())
+	}
+
+	if got := result.MessageID(); got != "message-1" {
+		t.Errorf("got message id %q, want message-1", got)
+	}
+}
+
+func TestEventServiceClientPushEventAsyncLimit(t *testing.T) {
+	release := make(chan struct{})
+
+	service := &eventv1fake.FakeEventService{}
+	service.PushEventStub = func(ctx context.Context, r *eventv1.PushEventRequest) (*eventv1.PushEventResponse, error) {
+		<-release
+		return &eventv1.PushEventResponse{}, nil
+	}
+
+	client := newTestEventServiceServer(t, service).(eventv1.AsyncEventServiceClient)
+	// unblock the server before it is closed
+	t.Cleanup(func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	})
+
+	ctx := context.Background()
+
+	var results []*eventv1.PushResult
+
+	for range eventv1sdk.DefaultMaxPendingEvents {
+		results = append(results, client.PushEventAsync(ctx, &eventv1.PushEventRequest{Event: newTestEvent(t)}))
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); service.PushEventCallCount() < eventv1sdk.DefaultMaxPendingEvents; {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d pending events, want %d", service.PushEventCallCount(), eventv1sdk.DefaultMaxPendingEvents)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the call does not block when the limit is reached
+	result := client.PushEventAsync(ctx, &eventv1.PushEventRequest{Event: newTestEvent(t)})
+
+	select {
+	case <-result.Ready():
+	default:
+		t.Fatal("the result is not ready")
+	}
+
+	if _, err := result.Get(ctx); !errors.Is(err, eventv1sdk.ErrTooManyPendingEvents) || connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrTooManyPendingEvents)
+	}
+
+	close(release)
+
+	for _, result := range results {
+		if _, err := result.Get(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := service.PushEventCallCount(); got != eventv1sdk.DefaultMaxPendingEvents {
+		t.Errorf("got %d pushed events, want %d", got, eventv1sdk.DefaultMaxPendingEvents)
+	}
+}
+
+func TestEventServiceReceipt(t *testing.T) {
+	event := newTestEvent(t)
+
+	service := &eventv1sdk.EventService{
+		EventHandler: eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+			return nil
+		}),
+	}
+
+	before := time.Now()
+
+	response, err := service.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: event})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := response.GetEventId(); got != event.GetId() {
+		t.Errorf("got event id %q, want %q", got, event.GetId())
+	}
+
+	if got := response.GetAcceptTime().AsTime(); got.Before(before) || got.After(time.Now()) {
+		t.Errorf("got accept time %v, want the push time", got)
+	}
+}
+
+func TestEventServiceHandlerReceipt(t *testing.T) {
+	accepted := timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	cases := []struct {
+		name     string
+		response *eventv1.PushEventResponse
+		want     func(*eventv1.Event) *eventv1.PushEventResponse
+	}{
+		{
+			name:     "defaults",
+			response: nil,
+			want: func(event *eventv1.Event) *eventv1.PushEventResponse {
+				return &eventv1.PushEventResponse{EventId: event.GetId()}
+			},
+		},
+		{
+			name: "service receipt",
+			response: &eventv1.PushEventResponse{
+				EventId:        "event-1",
+				MessageId:      "message-1",
+				AcceptTime:     accepted,
+				SequenceNumber: 42,
+			},
+			want: func(*eventv1.Event) *eventv1.PushEventResponse {
+				return &eventv1.PushEventResponse{
+					EventId:        "event-1",
+					MessageId:      "message-1",
+					AcceptTime:     accepted,
+					SequenceNumber: 42,
+				}
+			},
+		},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			service := &eventv1fake.FakeEventService{}
+			service.PushEventReturns(item.response, nil)
+
+			client := newTestEventServiceServer(t, service)
+
+			event := newTestEvent(t)
+
+			response, err := client.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: event})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if response.GetAcceptTime() == nil {
+				t.Error("missing accept time")
+			}
+
+			want := item.want(event)
+			if want.AcceptTime == nil {
+				// the accept time is set by the handler
+				want.AcceptTime = response.GetAcceptTime()
+			}
+
+			if !proto.Equal(response, want) {
+				t.Errorf("got %v, want %v", response, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package eventv1sdk
+
+import (
+	bytes "bytes"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	mime "mime"
+	http "net/http"
+	url "net/url"
+	strings "strings"
+
+	connect "connectrpc.com/connect"
+	middleware "github.com/connect-sdk/middleware"
+	chi "github.com/go-chi/chi/v5"
+	slogr "github.com/ralch/slogr"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+// ContentMode represents a CloudEvents protocol binding content mode.
+type ContentMode int
+
+const (
+	// ContentModeBinary carries the event attributes as protocol metadata and
+	// the event data as the message body.
+	ContentModeBinary ContentMode = iota
+	// ContentModeStructured carries the whole event in the message body.
+	ContentModeStructured
+)
+
+var (
+	// ErrMissingEvent is returned when a message does not carry a CloudEvent.
+	ErrMissingEvent = fmt.Errorf("no event")
+	// ErrUnsupportedContentMode is returned when a content mode is not supported.
+	ErrUnsupportedContentMode = fmt.Errorf("unsupported content mode")
+)
+
+// DefaultMaxBodySize is the default maximum size of a request body that the
+// EventHTTPHandler reads.
+const DefaultMaxBodySize int64 = 10 << 20
+
+// WriteHTTPRequest writes the event to the given request in the given content mode.
+func WriteHTTPRequest(r *http.Request, event *eventv1.Event, mode ContentMode) error {
+	data, err := writeHTTP(r.Header, event, mode)
+	if err != nil {
+		return err
+	}
+
+	r.ContentLength = int64(len(data))
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	return nil
+}
+
+// WriteHTTPResponse writes the event to the given response writer in the given
+// content mode with the http.StatusOK status code.
+func WriteHTTPResponse(w http.ResponseWriter, event *eventv1.Event, mode ContentMode) error {
+	data, err := writeHTTP(w.Header(), event, mode)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	// write the data
+	_, err = w.Write(data)
+	return err
+}
+
+// ReadHTTPRequest reads the event from the given request. The content mode is
+// detected from the Content-Type and the ce-specversion headers.
+func ReadHTTPRequest(r *http.Request) (*eventv1.Event, error) {
+	return readHTTP(r.Header, r.Body)
+}
+
+// ReadHTTPResponse reads the event from the given response. The content mode is
+// detected from the Content-Type and the ce-specversion headers.
+func ReadHTTPResponse(r *http.Response) (*eventv1.Event, error) {
+	return readHTTP(r.Header, r.Body)
+}
+
+// ReadHTTPBatchRequest reads the events from the given request. It accepts
+// the binary, the structured and the batch content modes.
+func ReadHTTPBatchRequest(r *http.Request) ([]*eventv1.Event, error) {
+	if !isHTTPBatch(r.Header) {
+		event, err := ReadHTTPRequest(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*eventv1.Event{event}, nil
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &eventv1.EventBatch{}
+	// unmarshal the batch
+	if err := batch.UnmarshalCloudEventJSON(data); err != nil {
+		return nil, err
+	}
+
+	return batch.Events, nil
+}
+
+var _ http.Handler = &EventHTTPHandler{}
+
+// EventHTTPHandler is an http.Handler that receives CloudEvents delivered
+// over plain HTTP in the binary, the structured or the batch content mode.
+type EventHTTPHandler struct {
+	// EventService contains an instance of cloud.event.v1.EventService service.
+	EventService eventv1.EventService
+	// Path is the path on which Mount mounts the handler. It defaults to all paths.
+	Path string
+	// Webhook enables the CloudEvents HTTP Webhook validation handshake.
+	Webhook *WebhookConfig
+	// MaxBodySize is the maximum size of a request body in bytes. It defaults
+	// to DefaultMaxBodySize.
+	MaxBodySize int64
+}
+
+// Mount mounts the handler to a given router.
+func (x *EventHTTPHandler) Mount(r chi.Router) {
+	path := x.Path
+	if path == "" {
+		path = "/*"
+	}
+
+	r.Group(func(r chi.Router) {
+		// mount the middleware
+		r.Use(middleware.WithLogger())
+		// mount the handler
+		r.Handle(path, x)
+	})
+}
+
+// ServeHTTP implements http.Handler. The events of a batch are validated
+// before any of them is pushed, so an invalid batch is rejected as a whole.
+// The valid events are pushed to the EventService in order. The handler stops
+// at the first event that fails and responds with the HTTP status that
+// corresponds to the error, so the events that precede it have been handled
+// already.
+func (x *EventHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if x.Webhook != nil {
+		WithWebhook(x.Webhook)(http.HandlerFunc(x.serveHTTP)).ServeHTTP(w, r)
+		return
+	}
+
+	x.serveHTTP(w, r)
+}
+
+func (x *EventHTTPHandler) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := x.MaxBodySize
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+	events, err := ReadHTTPBatchRequest(r)
+	if err != nil {
+		var xerr *http.MaxBytesError
+
+		switch {
+		case errors.As(err, &xerr):
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		case errors.Is(err, ErrMissingEvent):
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+
+		return
+	}
+
+	batch := make([]*eventv1.PushEventRequest, 0, len(events))
+	// validate the events
+	for index, event := range events {
+		args := &eventv1.PushEventRequest{
+			Event: event,
+		}
+
+		if err := args.ValidateSpec(); err != nil {
+			if len(events) > 1 {
+				err = &eventv1.EventBatchEntryError{Index: index, Err: err}
+			}
+
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		batch = append(batch, args)
+	}
+
+	ctx := r.Context()
+	// push the events
+	for _, args := range batch {
+		if _, err := x.EventService.PushEvent(ctx, args); err != nil {
+			logger := slogr.FromContext(ctx)
+			logger.ErrorContext(ctx, "cannot push an event", slogr.Error(err))
+
+			var xerr *connect.Error
+			// set the suggested retry delay
+			if errors.As(err, &xerr) && xerr.Meta().Get("Retry-After") != "" {
+				w.Header().Set("Retry-After", xerr.Meta().Get("Retry-After"))
+			}
+
+			status := httpStatusOf(err)
+			// do not expose the internal error
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// httpStatusOf returns the HTTP status code that corresponds to the error, as
+// defined by the Connect protocol.
+func httpStatusOf(err error) int {
+	switch connect.CodeOf(err) {
+	case connect.CodeCanceled:
+		return 499
+	case connect.CodeInvalidArgument, connect.CodeFailedPrecondition, connect.CodeOutOfRange:
+		return http.StatusBadRequest
+	case connect.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	case connect.CodeAlreadyExists, connect.CodeAborted:
+		return http.StatusConflict
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case connect.CodeUnimplemented:
+		return http.StatusNotImplemented
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func isHTTPBatch(header http.Header) bool {
+	mtype, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	// done!
+	return strings.EqualFold(mtype, eventv1.ContentTypeCloudEventsBatchJSON)
+}
+
+func writeHTTP(header http.Header, event *eventv1.Event, mode ContentMode) ([]byte, error) {
+	switch mode {
+	case ContentModeStructured:
+		data, err := event.MarshalCloudEventJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		header.Set("Content-Type", eventv1.ContentTypeCloudEventsJSON)
+		// done!
+		return data, nil
+	case ContentModeBinary:
+		args := &eventv1.PushEventRequest{
+			Event: event,
+		}
+
+		for key, value := range args.GetTypedAttributes() {
+			if key == "ce-datacontenttype" {
+				continue
+			}
+
+			header.Set(key, encodeHTTPHeaderValue(value))
+		}
+
+		if ctype := event.GetDataContentType(); ctype != "" {
+			header.Set("Content-Type", ctype)
+		}
+		// done!
+		return args.GetData(), nil
+	default:
+		return nil, ErrUnsupportedContentMode
+	}
+}
+
+func readHTTP(header http.Header, body io.Reader) (*eventv1.Event, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	ctype := header.Get("Content-Type")
+	// prepare the media type
+	mtype, _, _ := mime.ParseMediaType(ctype)
+
+	switch {
+	case strings.EqualFold(mtype, eventv1.ContentTypeCloudEventsJSON):
+		event := &eventv1.Event{}
+		// unmarshal the event
+		if err := event.UnmarshalCloudEventJSON(data); err != nil {
+			return nil, err
+		}
+
+		return event, nil
+	case header.Get("ce-specversion") != "":
+		attributes := make(map[string]string)
+		// prepare the attributes
+		for key, values := range header {
+			if len(values) == 0 || !strings.HasPrefix(strings.ToLower(key), "ce-") {
+				continue
+			}
+
+			attributes[key] = decodeHTTPHeaderValue(values[0])
+		}
+
+		if hint := header.Get(eventv1.AttributeKindsKey); hint != "" {
+			attributes[eventv1.AttributeKindsKey] = hint
+		}
+
+		if ctype != "" {
+			attributes["ce-datacontenttype"] = ctype
+		}
+
+		args := &eventv1.PushEventRequest{
+			Event: &eventv1.Event{},
+		}
+
+		// set the event attributes
+		if err := args.SetAttributes(attributes); err != nil {
+			return nil, err
+		}
+
+		// an empty body carries no data
+		if len(data) > 0 {
+			// set the event data
+			if err := args.SetData(data); err != nil {
+				return nil, err
+			}
+		}
+
+		return args.Event, nil
+	default:
+		return nil, ErrMissingEvent
+	}
+}
+
+// encodeHTTPHeaderValue percent-encodes the characters that the CloudEvents
+// HTTP binding does not allow in a header value.
+func encodeHTTPHeaderValue(value string) string {
+	var builder strings.Builder
+
+	for _, b := range []byte(value) {
+		if b <= 0x20 || b >= 0x7f || b == '"' || b == '%' {
+			fmt.Fprintf(&builder, "%%%02X", b)
+		} else {
+			builder.WriteByte(b)
+		}
+	}
+
+	return builder.String()
+}
+
+// decodeHTTPHeaderValue decodes a percent-encoded header value. It returns
+// the value as is when it is not a valid percent-encoded string.
+func decodeHTTPHeaderValue(value string) string {
+	if decoded, err := url.PathUnescape(value); err == nil {
+		return decoded
+	}
+
+	return value
+}
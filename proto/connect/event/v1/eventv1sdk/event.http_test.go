@@ -0,0 +1,304 @@
+package eventv1sdk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1fake"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func newTestEvent(t *testing.T) *eventv1.Event {
+	t.Helper()
+
+	event := eventv1.NewEvent()
+	event.SetType("com.example.order.created")
+	event.SetSource("/orders")
+	event.SetSubject("order 1 \"ü\" 100%")
+	event.SetTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	if err := event.SetDataWithContentType("application/json", []byte(`{"id":1}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	return event
+}
+
+func TestHTTPRequest(t *testing.T) {
+	modes := map[string]eventv1sdk.ContentMode{
+		"binary":     eventv1sdk.ContentModeBinary,
+		"structured": eventv1sdk.ContentModeStructured,
+	}
+
+	for name, mode := range modes {
+		t.Run(name, func(t *testing.T) {
+			event := newTestEvent(t)
+
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if err := eventv1sdk.WriteHTTPRequest(r, event, mode); err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := eventv1sdk.ReadHTTPRequest(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !proto.Equal(event, decoded) {
+				t.Errorf("got %v, want %v", decoded, event)
+			}
+		})
+	}
+}
+
+func TestHTTPRequestWithoutData(t *testing.T) {
+	event := newTestEvent(t)
+	event.Data = nil
+	delete(event.Attributes, "datacontenttype")
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	if err := eventv1sdk.WriteHTTPRequest(r, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := eventv1sdk.ReadHTTPRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded) {
+		t.Errorf("got %v, want %v", decoded, event)
+	}
+}
+
+func TestHTTPRequestTypedExtensions(t *testing.T) {
+	event := newTestEvent(t)
+
+	for name, value := range map[string]any{"extint": 42, "extbool": true} {
+		if err := event.SetExtension(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	if err := eventv1sdk.WriteHTTPRequest(r, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := r.Header.Get(eventv1.AttributeKindsKey); got != "extbool:Boolean,extint:Integer" {
+		t.Errorf("got hint %q, want the extension kinds", got)
+	}
+
+	if got := r.Header.Get("ce-" + eventv1.AttributeKindsKey); got != "" {
+		t.Errorf("the hint is written as the extension %q", got)
+	}
+
+	decoded, err := eventv1sdk.ReadHTTPRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded) {
+		t.Errorf("got %v, want %v", decoded, event)
+	}
+}
+
+func TestHTTPRequestHeaderEncoding(t *testing.T) {
+	event := newTestEvent(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	if err := eventv1sdk.WriteHTTPRequest(r, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "order%201%20%22%C3%BC%22%20100%25"
+	if got := r.Header.Get("ce-subject"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := r.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("got content type %q, want application/json", got)
+	}
+}
+
+func TestHTTPResponse(t *testing.T) {
+	event := newTestEvent(t)
+
+	w := httptest.NewRecorder()
+	if err := eventv1sdk.WriteHTTPResponse(w, event, eventv1sdk.ContentModeStructured); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusOK)
+	}
+
+	decoded, err := eventv1sdk.ReadHTTPResponse(w.Result())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded) {
+		t.Errorf("got %v, want %v", decoded, event)
+	}
+}
+
+func TestHTTPBatchRequest(t *testing.T) {
+	batch := &eventv1.EventBatch{
+		Events: []*eventv1.Event{newTestEvent(t), newTestEvent(t)},
+	}
+
+	data, err := batch.MarshalCloudEventJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+	r.Header.Set("Content-Type", eventv1.ContentTypeCloudEventsBatchJSON)
+
+	events, err := eventv1sdk.ReadHTTPBatchRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(batch, &eventv1.EventBatch{Events: events}) {
+		t.Errorf("got %v, want %v", events, batch.Events)
+	}
+}
+
+func TestHTTPRequestMissingEvent(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+	r.Header.Set("Content-Type", "text/plain")
+
+	if _, err := eventv1sdk.ReadHTTPRequest(r); err != eventv1sdk.ErrMissingEvent {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrMissingEvent)
+	}
+
+	if err := eventv1sdk.WriteHTTPRequest(r, newTestEvent(t), eventv1sdk.ContentMode(42)); err != eventv1sdk.ErrUnsupportedContentMode {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrUnsupportedContentMode)
+	}
+}
+
+func newTestBatchRequest(t *testing.T, events ...*eventv1.Event) *http.Request {
+	t.Helper()
+
+	batch := &eventv1.EventBatch{Events: events}
+
+	data, err := batch.MarshalCloudEventJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+	r.Header.Set("Content-Type", eventv1.ContentTypeCloudEventsBatchJSON)
+
+	return r
+}
+
+func TestEventHTTPHandler(t *testing.T) {
+	service := &eventv1fake.FakeEventService{}
+
+	handler := &eventv1sdk.EventHTTPHandler{
+		EventService: service,
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestBatchRequest(t, newTestEvent(t), newTestEvent(t)))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusNoContent)
+	}
+
+	if got := service.PushEventCallCount(); got != 2 {
+		t.Errorf("got %d pushed events, want 2", got)
+	}
+}
+
+func TestEventHTTPHandlerInvalidBatch(t *testing.T) {
+	service := &eventv1fake.FakeEventService{}
+
+	handler := &eventv1sdk.EventHTTPHandler{
+		EventService: service,
+	}
+
+	invalid := newTestEvent(t)
+	invalid.SetType("")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestBatchRequest(t, newTestEvent(t), invalid))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	if got := service.PushEventCallCount(); got != 0 {
+		t.Errorf("got %d pushed events, want none", got)
+	}
+}
+
+func TestEventHTTPHandlerMaxBodySize(t *testing.T) {
+	service := &eventv1fake.FakeEventService{}
+
+	handler := &eventv1sdk.EventHTTPHandler{
+		EventService: service,
+		MaxBodySize:  16,
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestBatchRequest(t, newTestEvent(t)))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	if got := service.PushEventCallCount(); got != 0 {
+		t.Errorf("got %d pushed events, want none", got)
+	}
+}
+
+func TestEventHTTPHandlerError(t *testing.T) {
+	handler := &eventv1sdk.EventHTTPHandler{
+		EventService: &eventv1sdk.EventService{
+			EventHandler: eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+				return eventv1.Retryable(errors.New("database password is secret"), 5*time.Second)
+			}),
+		},
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestBatchRequest(t, newTestEvent(t)))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	if got := w.Header().Get("Retry-After"); got != "5" {
+		t.Errorf("got Retry-After %q, want 5", got)
+	}
+
+	if strings.Contains(w.Body.String(), "secret") {
+		t.Errorf("the response exposes the internal error: %s", w.Body.String())
+	}
+}
+
+func TestEventHTTPHandlerMethod(t *testing.T) {
+	handler := &eventv1sdk.EventHTTPHandler{
+		EventService: &eventv1fake.FakeEventService{},
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,153 @@
+package eventv1sdk
+
+import (
+	mime "mime"
+	strings "strings"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+// KafkaHeader represents a header of a Kafka record.
+type KafkaHeader struct {
+	// Key is the header key.
+	Key string
+	// Value is the header value.
+	Value []byte
+}
+
+// KafkaRecord represents a Kafka record. It does not depend on a particular
+// Kafka client, the caller copies the fields to and from the client record.
+type KafkaRecord struct {
+	// Key is the record key.
+	Key []byte
+	// Value is the record value.
+	Value []byte
+	// Headers contains the record headers.
+	Headers []KafkaHeader
+}
+
+// GetHeader returns the value of the last header with the given key.
+func (x *KafkaRecord) GetHeader(key string) (string, bool) {
+	for index := len(x.Headers) - 1; index >= 0; index-- {
+		if header := x.Headers[index]; strings.EqualFold(header.Key, key) {
+			return string(header.Value), true
+		}
+	}
+
+	return "", false
+}
+
+// WriteKafkaRecord writes the event to the given record in the given content
+// mode. In the binary mode the attributes are written as ce_ headers, the type
+// of the extensions as the AttributeKindsKey header and the data content type
+// as the content-type header. In both modes the record key
+// is set to the partitionkey extension when the event has one.
+func WriteKafkaRecord(record *KafkaRecord, event *eventv1.Event, mode ContentMode) error {
+	switch mode {
+	case ContentModeStructured:
+		data, err := event.MarshalCloudEventJSON()
+		if err != nil {
+			return err
+		}
+
+		record.Value = data
+		record.Headers = append(record.Headers, KafkaHeader{
+			Key:   "content-type",
+			Value: []byte(eventv1.ContentTypeCloudEventsJSON),
+		})
+	case ContentModeBinary:
+		args := &eventv1.PushEventRequest{
+			Event: event,
+		}
+
+		for key, value := range args.GetTypedAttributes() {
+			switch key {
+			case "ce-datacontenttype":
+				continue
+			case eventv1.AttributeKindsKey:
+				// the hint is not a ce_ header
+			default:
+				key = "ce_" + strings.TrimPrefix(key, "ce-")
+			}
+
+			record.Headers = append(record.Headers, KafkaHeader{
+				Key:   key,
+				Value: []byte(value),
+			})
+		}
+
+		if ctype := event.GetDataContentType(); ctype != "" {
+			record.Headers = append(record.Headers, KafkaHeader{
+				Key:   "content-type",
+				Value: []byte(ctype),
+			})
+		}
+
+		record.Value = args.GetData()
+	default:
+		return ErrUnsupportedContentMode
+	}
+
+	if attr, ok := event.GetAttributes()["partitionkey"]; ok {
+		record.Key = []byte(attr.Format())
+	}
+
+	return nil
+}
+
+// ReadKafkaRecord reads the event from the given record. The content mode is
+// detected from the content-type and the ce_specversion headers.
+func ReadKafkaRecord(record *KafkaRecord) (*eventv1.Event, error) {
+	ctype, _ := record.GetHeader("content-type")
+	// prepare the media type
+	mtype, _, _ := mime.ParseMediaType(ctype)
+
+	switch {
+	case strings.EqualFold(mtype, eventv1.ContentTypeCloudEventsJSON):
+		event := &eventv1.Event{}
+		// unmarshal the event
+		if err := event.UnmarshalCloudEventJSON(record.Value); err != nil {
+			return nil, err
+		}
+
+		return event, nil
+	case hasKafkaHeader(record, "ce_specversion"):
+		attributes := make(map[string]string)
+		// prepare the attributes
+		for _, header := range record.Headers {
+			switch key := strings.ToLower(header.Key); {
+			case strings.HasPrefix(key, "ce_"):
+				attributes["ce-"+strings.TrimPrefix(key, "ce_")] = string(header.Value)
+			case key == eventv1.AttributeKindsKey:
+				attributes[eventv1.AttributeKindsKey] = string(header.Value)
+			}
+		}
+
+		if ctype != "" {
+			attributes["ce-datacontenttype"] = ctype
+		}
+
+		args := &eventv1.PushEventRequest{
+			Event: &eventv1.Event{},
+		}
+
+		// set the event attributes
+		if err := args.SetAttributes(attributes); err != nil {
+			return nil, err
+		}
+
+		// set the event data
+		if err := args.SetData(record.Value); err != nil {
+			return nil, err
+		}
+
+		return args.Event, nil
+	default:
+		return nil, ErrMissingEvent
+	}
+}
+
+func hasKafkaHeader(record *KafkaRecord, key string) bool {
+	_, ok := record.GetHeader(key)
+	return ok
+}
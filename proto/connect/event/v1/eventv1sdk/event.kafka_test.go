@@ -0,0 +1,133 @@
+package eventv1sdk_test
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func TestKafkaRecord(t *testing.T) {
+	modes := map[string]eventv1sdk.ContentMode{
+		"binary":     eventv1sdk.ContentModeBinary,
+		"structured": eventv1sdk.ContentModeStructured,
+	}
+
+	for name, mode := range modes {
+		t.Run(name, func(t *testing.T) {
+			event := newTestEvent(t)
+
+			if err := event.SetExtension("partitionkey", "customer-1"); err != nil {
+				t.Fatal(err)
+			}
+
+			record := &eventv1sdk.KafkaRecord{}
+			if err := eventv1sdk.WriteKafkaRecord(record, event, mode); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := string(record.Key); got != "customer-1" {
+				t.Errorf("got key %q, want customer-1", got)
+			}
+
+			decoded, err := eventv1sdk.ReadKafkaRecord(record)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !proto.Equal(event, decoded) {
+				t.Errorf("got %v, want %v", decoded, event)
+			}
+		})
+	}
+}
+
+func TestKafkaRecordTypedExtensions(t *testing.T) {
+	event := newTestEvent(t)
+
+	for name, value := range map[string]any{"extint": 42, "extbool": true} {
+		if err := event.SetExtension(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	record := &eventv1sdk.KafkaRecord{}
+	if err := eventv1sdk.WriteKafkaRecord(record, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := record.GetHeader(eventv1.AttributeKindsKey); got != "extbool:Boolean,extint:Integer" {
+		t.Errorf("got hint %q, want the extension kinds", got)
+	}
+
+	if got, ok := record.GetHeader("ce_" + eventv1.AttributeKindsKey); ok {
+		t.Errorf("the hint is written as the extension %q", got)
+	}
+
+	decoded, err := eventv1sdk.ReadKafkaRecord(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded) {
+		t.Errorf("got %v, want %v", decoded, event)
+	}
+}
+
+func TestKafkaRecordBinaryHeaders(t *testing.T) {
+	event := newTestEvent(t)
+
+	record := &eventv1sdk.KafkaRecord{}
+	if err := eventv1sdk.WriteKafkaRecord(record, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	headers := map[string]string{
+		"ce_specversion": "1.0",
+		"ce_id":          event.GetId(),
+		"ce_type":        event.GetType(),
+		"ce_subject":     event.GetSubject(),
+		"content-type":   "application/json",
+	}
+
+	for key, want := range headers {
+		if got, _ := record.GetHeader(key); got != want {
+			t.Errorf("got header %v %q, want %q", key, got, want)
+		}
+	}
+
+	if _, ok := record.GetHeader("ce_datacontenttype"); ok {
+		t.Error("the data content type is written as a ce_ header")
+	}
+
+	if got := string(record.Value); got != `{"id":1}` {
+		t.Errorf("got value %s", got)
+	}
+}
+
+func TestKafkaRecordLastHeader(t *testing.T) {
+	record := &eventv1sdk.KafkaRecord{
+		Headers: []eventv1sdk.KafkaHeader{
+			{Key: "ce_id", Value: []byte("1")},
+			{Key: "CE_ID", Value: []byte("2")},
+		},
+	}
+
+	if got, ok := record.GetHeader("ce_id"); !ok || got != "2" {
+		t.Errorf("got %q, %v, want 2", got, ok)
+	}
+}
+
+func TestKafkaRecordMissingEvent(t *testing.T) {
+	record := &eventv1sdk.KafkaRecord{Value: []byte("hello")}
+
+	if _, err := eventv1sdk.ReadKafkaRecord(record); err != eventv1sdk.ErrMissingEvent {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrMissingEvent)
+	}
+
+	if err := eventv1sdk.WriteKafkaRecord(record, newTestEvent(t), eventv1sdk.ContentMode(42)); err != eventv1sdk.ErrUnsupportedContentMode {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrUnsupportedContentMode)
+	}
+}
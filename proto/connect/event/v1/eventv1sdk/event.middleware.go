@@ -0,0 +1,119 @@
+package eventv1sdk
+
+import (
+	context "context"
+	fmt "fmt"
+	slog "log/slog"
+	time "time"
+
+	connect "connectrpc.com/connect"
+	slogr "github.com/ralch/slogr"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+// WithRecovery recovers the handler from any panic.
+func WithRecovery() eventv1.Middleware {
+	err := fmt.Errorf("the system is not in a state required for the operation's execution")
+
+	innerFn := func(next eventv1.EventHandler) eventv1.EventHandler {
+		fn := func(ctx context.Context, event *eventv1.Event) (xerr error) {
+			defer func() {
+				if r := recover(); r != nil {
+					failure := fmt.Sprintf("%v", r)
+					// prepare the logger
+					logger := slogr.FromContext(ctx)
+					logger.ErrorContext(ctx, "the system has an unexpected failure", slog.String("failure", failure))
+
+					// return the error
+					xerr = connect.NewError(connect.CodeInternal, err)
+				}
+			}()
+
+			return next.HandleEvent(ctx, event)
+		}
+
+		return eventv1.EventHandlerFunc(fn)
+	}
+
+	return innerFn
+}
+
+// WithTimeout limits the time that the handler has to handle an event.
+func WithTimeout(timeout time.Duration) eventv1.Middleware {
+	innerFn := func(next eventv1.EventHandler) eventv1.EventHandler {
+		fn := func(ctx context.Context, event *eventv1.Event) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return next.HandleEvent(ctx, event)
+		}
+
+		return eventv1.EventHandlerFunc(fn)
+	}
+
+	return innerFn
+}
+
+// WithLogger set up the logger with the event attributes and logs the outcome
+// of every event.
+func WithLogger() eventv1.Middleware {
+	innerFn := func(next eventv1.EventHandler) eventv1.EventHandler {
+		fn := func(ctx context.Context, event *eventv1.Event) error {
+			start := time.Now()
+
+			// prepare the logger attr
+			attr := slog.Group("event",
+				slog.String("id", event.GetId()),
+				slog.String("type", event.GetType()),
+				slog.String("source", event.GetSource()),
+				slog.String("subject", event.GetSubject()),
+			)
+
+			logger := slogr.FromContext(ctx)
+			logger = logger.With(attr)
+			// prepare the context
+			ctx = slogr.WithContext(ctx, logger)
+
+			// execute the handler
+			err := next.HandleEvent(ctx, event)
+
+			duration := slog.Duration("duration", time.Since(start))
+			// log the end
+			if err == nil {
+				logger.InfoContext(ctx, "handle an event", duration)
+			} else {
+				logger.ErrorContext(ctx, "handle an event", duration, slogr.Error(err))
+			}
+
+			return err
+		}
+
+		return eventv1.EventHandlerFunc(fn)
+	}
+
+	return innerFn
+}
+
+// WithContext set up the event context for the handler, so the handler can use
+// eventv1.FromContext regardless of the way the event arrived. The optional
+// functions enrich the context further.
+func WithContext(enrich ...func(context.Context, *eventv1.Event) context.Context) eventv1.Middleware {
+	innerFn := func(next eventv1.EventHandler) eventv1.EventHandler {
+		fn := func(ctx context.Context, event *eventv1.Event) error {
+			if ectx, ok := eventv1.FromContext(ctx); !ok || ectx.ID != event.GetId() {
+				ctx = eventv1.NewContext(ctx, eventv1.NewEventContext(event))
+			}
+
+			for _, enrichFn := range enrich {
+				ctx = enrichFn(ctx, event)
+			}
+
+			return next.HandleEvent(ctx, event)
+		}
+
+		return eventv1.EventHandlerFunc(fn)
+	}
+
+	return innerFn
+}
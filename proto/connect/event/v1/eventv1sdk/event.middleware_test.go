@@ -0,0 +1,136 @@
+package eventv1sdk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	slogr "github.com/ralch/slogr"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func TestWithRecovery(t *testing.T) {
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+		panic("boom")
+	}), eventv1sdk.WithRecovery())
+
+	err := handler.HandleEvent(context.Background(), newTestEvent(t))
+	if connect.CodeOf(err) != connect.CodeInternal {
+		t.Errorf("got %v, want %v", err, connect.CodeInternal)
+	}
+
+	if strings.Contains(err.Error(), "boom") {
+		t.Errorf("the error exposes the panic: %v", err)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(ctx context.Context, _ *eventv1.Event) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), eventv1sdk.WithTimeout(10*time.Millisecond))
+
+	err := handler.HandleEvent(context.Background(), newTestEvent(t))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if kind := eventv1.KindOf(err); kind != eventv1.ErrorKindRetryable {
+		t.Errorf("got kind %v, want %v", kind, eventv1.ErrorKindRetryable)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var buffer bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+	ctx := slogr.WithContext(context.Background(), logger)
+
+	event := newTestEvent(t)
+
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(ctx context.Context, _ *eventv1.Event) error {
+		slogr.FromContext(ctx).InfoContext(ctx, "inside")
+		return errors.New("failure")
+	}), eventv1sdk.WithLogger())
+
+	if err := handler.HandleEvent(ctx, event); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2", len(lines))
+	}
+
+	for _, line := range lines {
+		if !strings.Contains(line, `"id":"`+event.GetId()+`"`) {
+			t.Errorf("the log line has no event id: %s", line)
+		}
+	}
+
+	if !strings.Contains(lines[1], `"level":"ERROR"`) || !strings.Contains(lines[1], "duration") {
+		t.Errorf("got outcome %s", lines[1])
+	}
+}
+
+type testContextKey struct{}
+
+func TestWithContext(t *testing.T) {
+	event := newTestEvent(t)
+
+	var (
+		id    string
+		value any
+	)
+
+	enrich := func(ctx context.Context, event *eventv1.Event) context.Context {
+		return context.WithValue(ctx, testContextKey{}, event.GetType())
+	}
+
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(ctx context.Context, _ *eventv1.Event) error {
+		id = eventv1.GetEventID(ctx)
+		value = ctx.Value(testContextKey{})
+		return nil
+	}), eventv1sdk.WithContext(enrich))
+
+	if err := handler.HandleEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	if id != event.GetId() {
+		t.Errorf("got id %q, want %q", id, event.GetId())
+	}
+
+	if value != event.GetType() {
+		t.Errorf("got value %v, want %v", value, event.GetType())
+	}
+}
+
+func TestWithContextKeepsExisting(t *testing.T) {
+	event := newTestEvent(t)
+
+	ectx := eventv1.NewEventContext(event)
+	ectx.Attempt = 3
+
+	var attempt int
+
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(ctx context.Context, _ *eventv1.Event) error {
+		attempt = eventv1.GetEventAttempt(ctx)
+		return nil
+	}), eventv1sdk.WithContext())
+
+	if err := handler.HandleEvent(eventv1.NewContext(context.Background(), ectx), event); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempt != 3 {
+		t.Errorf("got attempt %d, want 3", attempt)
+	}
+}
@@ -0,0 +1,134 @@
+package eventv1sdk
+
+import (
+	mime "mime"
+	strings "strings"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+// MQTTUserProperty represents an MQTT 5 user property.
+type MQTTUserProperty struct {
+	// Key is the property key.
+	Key string
+	// Value is the property value.
+	Value string
+}
+
+// MQTTMessage represents an MQTT PUBLISH message. It does not depend on a
+// particular MQTT client, the caller copies the fields to and from the client
+// message. MQTT 3.1.1 carries only the Payload, so it supports only the
+// structured content mode.
+type MQTTMessage struct {
+	// ContentType is the MQTT 5 Content Type property.
+	ContentType string
+	// UserProperties contains the MQTT 5 user properties.
+	UserProperties []MQTTUserProperty
+	// Payload is the message payload.
+	Payload []byte
+}
+
+// GetUserProperty returns the value of the last user property with the given key.
+func (x *MQTTMessage) GetUserProperty(key string) (string, bool) {
+	for index := len(x.UserProperties) - 1; index >= 0; index-- {
+		if property := x.UserProperties[index]; strings.EqualFold(property.Key, key) {
+			return property.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// WriteMQTTMessage writes the event to the given message in the given content
+// mode. In the binary mode the attributes are written as MQTT 5 user
+// properties, the type of the extensions as the AttributeKindsKey user property
+// and the data content type as the Content Type property.
+func WriteMQTTMessage(msg *MQTTMessage, event *eventv1.Event, mode ContentMode) error {
+	switch mode {
+	case ContentModeStructured:
+		data, err := event.MarshalCloudEventJSON()
+		if err != nil {
+			return err
+		}
+
+		msg.Payload = data
+		msg.ContentType = eventv1.ContentTypeCloudEventsJSON
+	case ContentModeBinary:
+		args := &eventv1.PushEventRequest{
+			Event: event,
+		}
+
+		for key, value := range args.GetTypedAttributes() {
+			if key == "ce-datacontenttype" {
+				continue
+			}
+
+			msg.UserProperties = append(msg.UserProperties, MQTTUserProperty{
+				Key:   strings.TrimPrefix(key, "ce-"),
+				Value: value,
+			})
+		}
+
+		msg.Payload = args.GetData()
+		msg.ContentType = event.GetDataContentType()
+	default:
+		return ErrUnsupportedContentMode
+	}
+
+	return nil
+}
+
+// ReadMQTTMessage reads the event from the given message. The content mode is
+// detected from the Content Type and the specversion user property. A message
+// without both of them is read as an MQTT 3.1.1 message in the structured
+// content mode.
+func ReadMQTTMessage(msg *MQTTMessage) (*eventv1.Event, error) {
+	mtype, _, _ := mime.ParseMediaType(msg.ContentType)
+
+	_, binary := msg.GetUserProperty("specversion")
+
+	switch {
+	case strings.EqualFold(mtype, eventv1.ContentTypeCloudEventsJSON),
+		msg.ContentType == "" && !binary:
+		event := &eventv1.Event{}
+		// unmarshal the event
+		if err := event.UnmarshalCloudEventJSON(msg.Payload); err != nil {
+			return nil, err
+		}
+
+		return event, nil
+	case binary:
+		attributes := make(map[string]string)
+		// prepare the attributes
+		for _, property := range msg.UserProperties {
+			if strings.EqualFold(property.Key, eventv1.AttributeKindsKey) {
+				attributes[eventv1.AttributeKindsKey] = property.Value
+				continue
+			}
+
+			attributes["ce-"+strings.ToLower(property.Key)] = property.Value
+		}
+
+		if msg.ContentType != "" {
+			attributes["ce-datacontenttype"] = msg.ContentType
+		}
+
+		args := &eventv1.PushEventRequest{
+			Event: &eventv1.Event{},
+		}
+
+		// set the event attributes
+		if err := args.SetAttributes(attributes); err != nil {
+			return nil, err
+		}
+
+		// set the event data
+		if err := args.SetData(msg.Payload); err != nil {
+			return nil, err
+		}
+
+		return args.Event, nil
+	default:
+		return nil, ErrMissingEvent
+	}
+}
@@ -0,0 +1,117 @@
+package eventv1sdk_test
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func TestMQTTMessage(t *testing.T) {
+	modes := map[string]eventv1sdk.ContentMode{
+		"binary":     eventv1sdk.ContentModeBinary,
+		"structured": eventv1sdk.ContentModeStructured,
+	}
+
+	for name, mode := range modes {
+		t.Run(name, func(t *testing.T) {
+			event := newTestEvent(t)
+
+			msg := &eventv1sdk.MQTTMessage{}
+			if err := eventv1sdk.WriteMQTTMessage(msg, event, mode); err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := eventv1sdk.ReadMQTTMessage(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !proto.Equal(event, decoded) {
+				t.Errorf("got %v, want %v", decoded, event)
+			}
+		})
+	}
+}
+
+func TestMQTTMessageTypedExtensions(t *testing.T) {
+	event := newTestEvent(t)
+
+	for name, value := range map[string]any{"extint": 42, "extbool": true} {
+		if err := event.SetExtension(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	event.Attributes["exturi"] = &eventv1.EventAttributeValue{
+		Attr: &eventv1.EventAttributeValue_CeUri{CeUri: "https://example.com/a"},
+	}
+
+	msg := &eventv1sdk.MQTTMessage{}
+	if err := eventv1sdk.WriteMQTTMessage(msg, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := msg.GetUserProperty(eventv1.AttributeKindsKey); got != "extbool:Boolean,extint:Integer,exturi:URI" {
+		t.Errorf("got hint %q, want the extension kinds", got)
+	}
+
+	decoded, err := eventv1sdk.ReadMQTTMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded) {
+		t.Errorf("got %v, want %v", decoded, event)
+	}
+}
+
+func TestMQTTMessageBinaryProperties(t *testing.T) {
+	event := newTestEvent(t)
+
+	msg := &eventv1sdk.MQTTMessage{}
+	if err := eventv1sdk.WriteMQTTMessage(msg, event, eventv1sdk.ContentModeBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := msg.GetUserProperty("specversion"); got != "1.0" {
+		t.Errorf("got specversion %q, want 1.0", got)
+	}
+
+	if got, _ := msg.GetUserProperty("subject"); got != event.GetSubject() {
+		t.Errorf("got subject %q, want %q", got, event.GetSubject())
+	}
+
+	if _, ok := msg.GetUserProperty("datacontenttype"); ok {
+		t.Error("the data content type is written as a user property")
+	}
+}
+
+func TestMQTTMessageV311(t *testing.T) {
+	event := newTestEvent(t)
+
+	data, err := event.MarshalCloudEventJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an MQTT 3.1.1 message carries only the payload
+	decoded, err := eventv1sdk.ReadMQTTMessage(&eventv1sdk.MQTTMessage{Payload: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(event, decoded) {
+		t.Errorf("got %v, want %v", decoded, event)
+	}
+}
+
+func TestMQTTMessageMissingEvent(t *testing.T) {
+	msg := &eventv1sdk.MQTTMessage{ContentType: "text/plain", Payload: []byte("hello")}
+
+	if _, err := eventv1sdk.ReadMQTTMessage(msg); err != eventv1sdk.ErrMissingEvent {
+		t.Errorf("got %v, want %v", err, eventv1sdk.ErrMissingEvent)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	context "context"
 	fmt "fmt"
 	slog "log/slog"
+	sync "sync"
 
 	pubsub "cloud.google.com/go/pubsub"
 	connect "connectrpc.com/connect"
 	pubsubv1 "github.com/connect-sdk/pubsub-api/proto/connect/pubsub/v1"
 	slogr "github.com/ralch/slogr"
 	option "google.golang.org/api/option"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 
 	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
 )
@@ -17,36 +19,61 @@ import (
 var _ pubsubv1.PubsubService = &EventPubsubService{}
 
 // EventPubsubService is an implementation of the google.pubsub.v1.EventPubsubService service.
+// A successful response acknowledges the message. The errors of the
+// EventService are mapped to the delivery outcome as described by EventService.
+// A message that does not carry a valid event fails permanently, so it is
+// acknowledged and passed to the optional ErrorHandler.
 type EventPubsubService struct {
 	// EventService contains an instance of cloud.event.v1.EventService service.
 	EventService eventv1.EventService
+	// ErrorHandler receives the messages that do not carry a valid event. It
+	// is optional. The message is delivered again when the ErrorHandler fails.
+	ErrorHandler eventv1.ErrorHandler
 }
 
 // PushPubsubMessage implements google.pubsub.v1.PubsubService.
 func (x *EventPubsubService) PushPubsubMessage(ctx context.Context, r *pubsubv1.PushPubsubMessageRequest) (*pubsubv1.PushPubsubMessageResponse, error) {
+	args, err := decodePubsubMessage(r.GetMessage().GetAttributes(), r.GetMessage().GetData())
+	if err != nil {
+		if err := handleEventError(ctx, x.ErrorHandler, args.Event, err); err != nil {
+			return nil, err
+		}
+	} else {
+		// push the event
+		if _, err := x.EventService.PushEvent(ctx, args); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &pubsubv1.PushPubsubMessageResponse{}
+	// done!
+	return response, nil
+}
+
+// decodePubsubMessage decodes the event of a Pub/Sub message. A decode failure
+// is a permanent error. The returned request carries the attributes that have
+// been decoded and the raw message data in this case.
+func decodePubsubMessage(attributes map[string]string, data []byte) (*eventv1.PushEventRequest, error) {
 	// prepare the arg
 	args := &eventv1.PushEventRequest{
 		Event: &eventv1.Event{},
 	}
 
 	// set the event attributes
-	if err := args.SetAttributes(r.Message.Attributes); err != nil {
-		return nil, err
+	if err := args.SetAttributes(attributes); err != nil {
+		args.Event.Data = &eventv1.Event_BinaryData{BinaryData: data}
+		// done!
+		return args, eventv1.Permanent(fmt.Errorf("cannot read the event attributes: %w", err))
 	}
 
 	// set the event data
-	if err := args.SetData(r.Message.Data); err != nil {
-		return nil, err
+	if err := args.SetData(data); err != nil {
+		args.Event.Data = &eventv1.Event_BinaryData{BinaryData: data}
+		// done!
+		return args, eventv1.Permanent(&eventv1.DataError{Err: err})
 	}
 
-	// push the event
-	if _, err := x.EventService.PushEvent(ctx, args); err != nil {
-		return nil, err
-	}
-
-	response := &pubsubv1.PushPubsubMessageResponse{}
-	// done!
-	return response, nil
+	return args, nil
 }
 
 var (
@@ -64,18 +91,37 @@ type PubsubEventServiceClientConfig struct {
 	Topic string
 	// Options contains the client Options
 	Options []option.ClientOption
+	// OrderingKey returns the ordering key of the published message. The
+	// messages with the same ordering key are delivered in the publish order
+	// when the subscription enables message ordering. Use OrderingKeyNone,
+	// OrderingKeySubject, OrderingKeyPartitionKey or a custom function. It
+	// defaults to OrderingKeyNone, which disables message ordering.
+	OrderingKey OrderingKeyFunc
+	// PublishSettings contains the batching and flow control settings of the
+	// publisher. It defaults to pubsub.DefaultPublishSettings.
+	PublishSettings *pubsub.PublishSettings
 }
 
-var _ eventv1.EventServiceClient = &PubsubEventServiceClient{}
+var _ eventv1.CloseableEventServiceClient = &PubsubEventServiceClient{}
 
-// EventServiceConn is a client for the cloud.event.v1.EventService service.
+var _ eventv1.AsyncEventServiceClient = &PubsubEventServiceClient{}
+
+// PubsubEventServiceClient is a client for the cloud.event.v1.EventService service. It
+// publishes the events with a long-lived publisher that batches the messages,
+// so the client should be closed with Close.
 type PubsubEventServiceClient struct {
-	client *pubsub.Client
-	topic  string
+	client      *pubsub.Client
+	topic       *pubsub.Topic
+	orderingKey OrderingKeyFunc
+	closeOnce   sync.Once
+	closeErr    error
 }
 
-// NewPubsubEventServiceClient creates a new cloud.event.v1.EventServiceClient client.
-func NewPubsubEventServiceClient(ctx context.Context, config *PubsubEventServiceClientConfig) (eventv1.EventServiceClient, error) {
+// NewPubsubEventServiceClient creates a new cloud.event.v1.EventServiceClient
+// client. The client is a *PubsubEventServiceClient unless the config is nil,
+// in which case it is a no-op client. The client should be closed with Close.
+// The *PubsubEventServiceClient implements eventv1.AsyncEventServiceClient as well.
+func NewPubsubEventServiceClient(ctx context.Context, config *PubsubEventServiceClientConfig) (eventv1.CloseableEventServiceClient, error) {
 	if config == nil {
 		return &eventv1.NopEventServiceClient{}, nil
 	}
@@ -94,10 +140,19 @@ func NewPubsubEventServiceClient(ctx context.Context, config *PubsubEventService
 		return nil, err
 	}
 
+	// prepare the publisher
+	topic := client.Topic(config.Topic)
+	topic.EnableMessageOrdering = config.OrderingKey != nil
+
+	if config.PublishSettings != nil {
+		topic.PublishSettings = *config.PublishSettings
+	}
+
 	// prepare the broker
 	connector := &PubsubEventServiceClient{
-		topic:  config.Topic,
-		client: client,
+		topic:       topic,
+		client:      client,
+		orderingKey: config.OrderingKey,
 	}
 
 	// done!
@@ -106,11 +161,24 @@ func NewPubsubEventServiceClient(ctx context.Context, config *PubsubEventService
 
 // PushEvent pushes a given event to cloud.event.v1.EventService service.
 func (x *PubsubEventServiceClient) PushEvent(ctx context.Context, r *eventv1.PushEventRequest) (*eventv1.PushEventResponse, error) {
+	return x.PushEventAsync(ctx, r).Get(ctx)
+}
+
+// PushEventAsync pushes a given event to cloud.event.v1.EventService service
+// without waiting for the publisher. The message is published in the next
+// batch of the publisher. The publisher pauses the ordering key of a failed
+// message, so the later messages with the same key fail as well and the order
+// is kept. The key is resumed when the failed result is retrieved with Get or
+// MessageID, or by ResumePublish.
+func (x *PubsubEventServiceClient) PushEventAsync(ctx context.Context, r *eventv1.PushEventRequest) *eventv1.PushResult {
 	// prepare the message
 	message := &pubsub.Message{
-		Data:        r.GetData(),
-		Attributes:  r.GetAttributes(),
-		OrderingKey: r.GetOrderingKey(),
+		Data:       r.GetData(),
+		Attributes: r.GetTypedAttributes(),
+	}
+
+	if x.orderingKey != nil {
+		message.OrderingKey = x.orderingKey(r.Event)
 	}
 
 	// prepare the logger attr
@@ -125,14 +193,74 @@ func (x *PubsubEventServiceClient) PushEvent(ctx context.Context, r *eventv1.Pus
 	// prepare the logger message
 	logger.Info("push an event", attr)
 
-	topic := x.client.Topic(x.topic)
-	topic.EnableMessageOrdering = true
+	// the event is accepted when it is handed to the publisher
+	accepted := timestamppb.Now()
 	// publish the message
-	if _, err := topic.Publish(ctx, message).Get(ctx); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	result := x.topic.Publish(ctx, message)
+
+	resolve := func() (string, *eventv1.PushEventResponse, error) {
+		// the result is ready, so Get does not block
+		id, err := result.Get(context.Background())
+		if err != nil {
+			if message.OrderingKey != "" {
+				// the caller has seen the failure
+				x.topic.ResumePublish(message.OrderingKey)
+			}
+
+			return "", nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		response := &eventv1.PushEventResponse{
+			EventId:    r.Event.GetId(),
+			MessageId:  id,
+			AcceptTime: accepted,
+		}
+		// done!
+		return id, response, nil
 	}
 
-	response := &eventv1.PushEventResponse{}
-	// done!
-	return response, nil
+	return eventv1.NewPushResult(result.Ready(), resolve)
+}
+
+// ResumePublish resumes the publishing of the given ordering key, which the
+// publisher pauses after a failed message. It is needed only when the failed
+// result is not retrieved.
+func (x *PubsubEventServiceClient) ResumePublish(key string) {
+	x.topic.ResumePublish(key)
+}
+
+// Close publishes the pending messages and closes the client. The pending
+// messages are abandoned when the context is done. The subsequent calls
+// return the result of the first one.
+func (x *PubsubEventServiceClient) Close(ctx context.Context) error {
+	x.closeOnce.Do(func() {
+		x.closeErr = x.close(ctx)
+	})
+
+	return x.closeErr
+}
+
+func (x *PubsubEventServiceClient) close(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		// flush the pending messages
+		x.topic.Stop()
+	}()
+
+	if err := ctx.Err(); err != nil {
+		// abandon the pending messages
+		x.client.Close()
+		return err
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		x.client.Close()
+		return ctx.Err()
+	}
+
+	return x.client.Close()
 }
@@ -0,0 +1,475 @@
+package eventv1sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pubsub "cloud.google.com/go/pubsub"
+	pubsubpb "cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	pstest "cloud.google.com/go/pubsub/pstest"
+	connect "connectrpc.com/connect"
+	pubsubv1 "github.com/connect-sdk/pubsub-api/proto/connect/pubsub/v1"
+	option "google.golang.org/api/option"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	insecure "google.golang.org/grpc/credentials/insecure"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1fake"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func newTestPubsubMessage(t *testing.T, event *eventv1.Event) *pubsubv1.PubsubMessage {
+	t.Helper()
+
+	args := &eventv1.PushEventRequest{Event: event}
+
+	return &pubsubv1.PubsubMessage{
+		Attributes: args.GetTypedAttributes(),
+		Data:       args.GetData(),
+	}
+}
+
+func TestEventPubsubService(t *testing.T) {
+	event := newTestEvent(t)
+	_ = event.SetExtension("extint", 42)
+
+	service := &eventv1fake.FakeEventService{}
+
+	handler := &eventv1sdk.EventPubsubService{
+		EventService: service,
+	}
+
+	request := &pubsubv1.PushPubsubMessageRequest{
+		Message: newTestPubsubMessage(t, event),
+	}
+
+	if _, err := handler.PushPubsubMessage(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	if service.PushEventCallCount() != 1 {
+		t.Fatalf("got %d pushed events, want 1", service.PushEventCallCount())
+	}
+
+	if _, args := service.PushEventArgsForCall(0); !proto.Equal(args.Event, event) {
+		t.Errorf("got %v, want %v", args.Event, event)
+	}
+}
+
+func TestEventPubsubServiceMalformed(t *testing.T) {
+	valid := newTestPubsubMessage(t, newTestEvent(t))
+
+	cases := []struct {
+		name    string
+		message *pubsubv1.PubsubMessage
+	}{
+		{"invalid attribute", &pubsubv1.PubsubMessage{
+			Attributes: map[string]string{"ce-id": "1", eventv1.AttributeKindsKey: "ext:Integer", "ce-ext": "a"},
+			Data:       []byte("raw"),
+		}},
+		{"invalid data", &pubsubv1.PubsubMessage{
+			Attributes: map[string]string{"ce-id": "1", "ce-datacontenttype": eventv1.ContentTypeCloudEventsProtobuf},
+			Data:       []byte("raw"),
+		}},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			service := &eventv1fake.FakeEventService{}
+			errorHandler := &eventv1fake.FakeErrorHandler{}
+
+			handler := &eventv1sdk.EventPubsubService{
+				EventService: service,
+				ErrorHandler: errorHandler,
+			}
+
+			request := &pubsubv1.PushPubsubMessageRequest{Message: item.message}
+
+			// the message is acknowledged
+			if _, err := handler.PushPubsubMessage(context.Background(), request); err != nil {
+				t.Fatal(err)
+			}
+
+			if service.PushEventCallCount() != 0 {
+				t.Error("a malformed message is pushed")
+			}
+
+			if errorHandler.HandleErrorCallCount() != 1 {
+				t.Fatalf("got %d forwarded messages, want 1", errorHandler.HandleErrorCallCount())
+			}
+
+			_, event, err := errorHandler.HandleErrorArgsForCall(0)
+			if kind := eventv1.KindOf(err); kind != eventv1.ErrorKindPermanent {
+				t.Errorf("got kind %v, want %v", kind, eventv1.ErrorKindPermanent)
+			}
+
+			if got := string(event.GetBinaryData()); got != "raw" {
+				t.Errorf("got data %q, want the raw message data", got)
+			}
+		})
+	}
+
+	t.Run("forward failure", func(t *testing.T) {
+		errorHandler := &eventv1fake.FakeErrorHandler{}
+		errorHandler.HandleErrorReturns(errors.New("unavailable"))
+
+		handler := &eventv1sdk.EventPubsubService{
+			EventService: &eventv1fake.FakeEventService{},
+			ErrorHandler: errorHandler,
+		}
+
+		message := proto.Clone(valid).(*pubsubv1.PubsubMessage)
+		message.Attributes["ce-time"] = "now"
+
+		_, err := handler.PushPubsubMessage(context.Background(), &pubsubv1.PushPubsubMessageRequest{Message: message})
+		if connect.CodeOf(err) != connect.CodeUnavailable {
+			t.Errorf("got %v, want %v", err, connect.CodeUnavailable)
+		}
+	})
+}
+
+func newTestPubsubServer(t *testing.T) (*pstest.Server, []option.ClientOption) {
+	t.Helper()
+
+	server := pstest.NewServer()
+	t.Cleanup(func() { server.Close() })
+
+	ctx := context.Background()
+
+	if _, err := server.GServer.CreateTopic(ctx, &pubsubpb.Topic{Name: "projects/test/topics/events"}); err != nil {
+		t.Fatal(err)
+	}
+
+	subscription := &pubsubpb.Subscription{
+		Name:               "projects/test/subscriptions/events",
+		Topic:              "projects/test/topics/events",
+		AckDeadlineSeconds: 10,
+	}
+
+	if _, err := server.GServer.CreateSubscription(ctx, subscription); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := grpc.NewClient(server.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return server, []option.ClientOption{option.WithGRPCConn(conn)}
+}
+
+func newTestPubsubClient(t *testing.T, config *eventv1sdk.PubsubEventServiceClientConfig) *eventv1sdk.PubsubEventServiceClient {
+	t.Helper()
+
+	client, err := eventv1sdk.NewPubsubEventServiceClient(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = client.Close(context.Background()) })
+
+	return client.(*eventv1sdk.PubsubEventServiceClient)
+}
+
+func TestPubsubEventServiceClientOrderingKey(t *testing.T) {
+	cases := []struct {
+		name string
+		key  eventv1sdk.OrderingKeyFunc
+		want string
+	}{
+		{"none", eventv1sdk.OrderingKeyNone, ""},
+		{"subject", eventv1sdk.OrderingKeySubject, "order-1"},
+		{"partition key", eventv1sdk.OrderingKeyPartitionKey, "customer-1"},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			server, options := newTestPubsubServer(t)
+
+			client := newTestPubsubClient(t, &eventv1sdk.PubsubEventServiceClientConfig{
+				Project:     "test",
+				Topic:       "events",
+				Options:     options,
+				OrderingKey: item.key,
+			})
+
+			event := newTestEvent(t)
+			event.SetSubject("order-1")
+			_ = event.SetExtension("partitionkey", "customer-1")
+
+			if _, err := client.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: event}); err != nil {
+				t.Fatal(err)
+			}
+
+			messages := server.Messages()
+			if len(messages) != 1 {
+				t.Fatalf("got %d messages, want 1", len(messages))
+			}
+
+			if got := messages[0].OrderingKey; got != item.want {
+				t.Errorf("got ordering key %q, want %q", got, item.want)
+			}
+		})
+	}
+}
+
+func TestPushEventRequestGetOrderingKey(t *testing.T) {
+	event := newTestEvent(t)
+
+	//nolint:staticcheck // the deprecated method keeps its behavior
+	if got := (&eventv1.PushEventRequest{Event: event}).GetOrderingKey(); got != event.GetId() {
+		t.Errorf("got %q, want the event id", got)
+	}
+}
+
+func TestNewPubsubEventServiceClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil config", func(t *testing.T) {
+		client, err := eventv1sdk.NewPubsubEventServiceClient(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := client.(*eventv1.NopEventServiceClient); !ok {
+			t.Errorf("got %T, want a no-op client", client)
+		}
+
+		if err := client.Close(ctx); err != nil {
+			t.Error(err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		config *eventv1sdk.PubsubEventServiceClientConfig
+		want   error
+	}{
+		{"missing project", &eventv1sdk.PubsubEventServiceClientConfig{Topic: "events"}, eventv1sdk.ErrMissingProject},
+		{"missing topic", &eventv1sdk.PubsubEventServiceClientConfig{Project: "test"}, eventv1sdk.ErrMissingTopic},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			if _, err := eventv1sdk.NewPubsubEventServiceClient(ctx, item.config); !errors.Is(err, item.want) {
+				t.Errorf("got %v, want %v", err, item.want)
+			}
+		})
+	}
+}
+
+func TestPubsubEventServiceClientBatching(t *testing.T) {
+	server, options := newTestPubsubServer(t)
+
+	settings := pubsub.DefaultPublishSettings
+	settings.CountThreshold = 3
+	settings.DelayThreshold = time.Hour
+
+	client := newTestPubsubClient(t, &eventv1sdk.PubsubEventServiceClientConfig{
+		Project:         "test",
+		Topic:           "events",
+		Options:         options,
+		PublishSettings: &settings,
+	})
+
+	ctx := context.Background()
+
+	var results []*eventv1.PushResult
+
+	for range 2 {
+		results = append(results, client.PushEventAsync(ctx, &eventv1.PushEventRequest{Event: newTestEvent(t)}))
+	}
+
+	select {
+	case <-results[0].Ready():
+		t.Fatal("the message is published before the batch is full")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if got := len(server.Messages()); got != 0 {
+		t.Fatalf("got %d messages, want 0", got)
+	}
+
+	results = append(results, client.PushEventAsync(ctx, &eventv1.PushEventRequest{Event: newTestEvent(t)}))
+
+	for _, result := range results {
+		if _, err := result.Get(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := len(server.Messages()); got != 3 {
+		t.Errorf("got %d messages, want 3", got)
+	}
+}
+
+func TestPubsubEventServiceClientClose(t *testing.T) {
+	settings := pubsub.DefaultPublishSettings
+	settings.CountThreshold = 100
+	settings.DelayThreshold = time.Hour
+
+	t.Run("flush", func(t *testing.T) {
+		server, options := newTestPubsubServer(t)
+
+		client := newTestPubsubClient(t, &eventv1sdk.PubsubEventServiceClientConfig{
+			Project:         "test",
+			Topic:           "events",
+			Options:         options,
+			PublishSettings: &settings,
+		})
+
+		ctx := context.Background()
+
+		result := client.PushEventAsync(ctx, &eventv1.PushEventRequest{Event: newTestEvent(t)})
+
+		if err := client.Close(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := result.Get(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := len(server.Messages()); got != 1 {
+			t.Errorf("got %d messages, want 1", got)
+		}
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		_, options := newTestPubsubServer(t)
+
+		client := newTestPubsubClient(t, &eventv1sdk.PubsubEventServiceClientConfig{
+			Project:         "test",
+			Topic:           "events",
+			Options:         options,
+			PublishSettings: &settings,
+		})
+
+		client.PushEventAsync(context.Background(), &eventv1.PushEventRequest{Event: newTestEvent(t)})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := client.Close(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func TestPubsubEventServiceClientResumePublish(t *testing.T) {
+	server, options := newTestPubsubServer(t)
+	server.SetAutoPublishResponse(false)
+	server.AddPublishResponse(nil, status.Error(codes.InvalidArgument, "oops"))
+	server.AddPublishResponse(&pubsubpb.PublishResponse{MessageIds: []string{"message-2"}}, nil)
+	server.AddPublishResponse(nil, status.Error(codes.InvalidArgument, "oops"))
+	server.AddPublishResponse(&pubsubpb.PublishResponse{MessageIds: []string{"message-4"}}, nil)
+
+	client := newTestPubsubClient(t, &eventv1sdk.PubsubEventServiceClientConfig{
+		Project:     "test",
+		Topic:       "events",
+		Options:     options,
+		OrderingKey: eventv1sdk.OrderingKeySubject,
+	})
+
+	ctx := context.Background()
+	event := newTestEvent(t)
+
+	push := func() (*eventv1.PushEventResponse, error) {
+		return client.PushEvent(ctx, &eventv1.PushEventRequest{Event: newTestEvent(t)})
+	}
+
+	t.Run("resume publish", func(t *testing.T) {
+		// the result of the failed message is not retrieved
+		failed := client.PushEventAsync(ctx, &eventv1.PushEventRequest{Event: event})
+		<-failed.Ready()
+
+		// the ordering key stays paused
+		paused := client.PushEventAsync(ctx, &eventv1.PushEventRequest{Event: newTestEvent(t)})
+		<-paused.Ready()
+
+		client.ResumePublish(event.GetSubject())
+
+		response, err := push()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := response.GetMessageId(); got != "message-2" {
+			t.Errorf("got message id %q, want message-2", got)
+		}
+
+		for _, result := range []*eventv1.PushResult{failed, paused} {
+			if _, err := result.Get(ctx); connect.CodeOf(err) != connect.CodeInternal {
+				t.Errorf("got %v, want %v", err, connect.CodeInternal)
+			}
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		// the retrieved failure resumes the ordering key
+		if _, err := push(); connect.CodeOf(err) != connect.CodeInternal {
+			t.Fatalf("got %v, want %v", err, connect.CodeInternal)
+		}
+
+		response, err := push()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := response.GetMessageId(); got != "message-4" {
+			t.Errorf("got message id %q, want message-4", got)
+		}
+	})
+}
+
+func TestPubsubEventServiceClientReceipt(t *testing.T) {
+	server, options := newTestPubsubServer(t)
+
+	client := newTestPubsubClient(t, &eventv1sdk.PubsubEventServiceClientConfig{
+		Project: "test",
+		Topic:   "events",
+		Options: options,
+	})
+
+	ctx := context.Background()
+	event := newTestEvent(t)
+	before := time.Now()
+
+	result := client.PushEventAsync(ctx, &eventv1.PushEventRequest{Event: event})
+	<-result.Ready()
+
+	published := time.Now()
+	// the result is retrieved later
+	time.Sleep(50 * time.Millisecond)
+
+	response, err := result.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+
+	if got := response.GetMessageId(); got == "" || got != messages[0].ID {
+		t.Errorf("got message id %q, want %q", got, messages[0].ID)
+	}
+
+	if got := result.MessageID(); got != messages[0].ID {
+		t.Errorf("got result message id %q, want %q", got, messages[0].ID)
+	}
+
+	if got := response.GetEventId(); got != event.GetId() {
+		t.Errorf("got event id %q, want %q", got, event.GetId())
+	}
+
+	if got := response.GetAcceptTime().AsTime(); got.Before(before) || got.After(published) {
+		t.Errorf("got accept time %v, want the publish time", got)
+	}
+}
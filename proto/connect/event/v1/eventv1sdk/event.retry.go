@@ -0,0 +1,146 @@
+package eventv1sdk
+
+import (
+	context "context"
+	slog "log/slog"
+	rand "math/rand/v2"
+	time "time"
+
+	slogr "github.com/ralch/slogr"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+// RetryConfig represents a configuration for the retry middleware.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. It defaults to 3.
+	MaxAttempts int
+	// MaxElapsedTime limits the time spent on all attempts. Zero means no limit.
+	MaxElapsedTime time.Duration
+	// InitialInterval is the delay before the second attempt. It defaults to
+	// 100 milliseconds.
+	InitialInterval time.Duration
+	// MaxInterval limits the delay between two attempts. It defaults to 10 seconds.
+	MaxInterval time.Duration
+	// Multiplier is the growth factor of the delay. It defaults to 2.
+	Multiplier float64
+	// Jitter is the relative randomization of the delay. A jitter of 0.2
+	// randomizes the delay in the [0.8, 1.2] range of its value. It defaults
+	// to 0.2. A negative value disables the randomization.
+	Jitter float64
+}
+
+// WithRetry handles the event again with an exponential backoff when the
+// handler fails. The permanent failures and the skipped events are not
+// retried. The delay of a retryable failure is at least its suggested delay.
+// The middleware gives up when the next attempt would exceed the maximum
+// number of attempts, the maximum elapsed time or the context deadline, and
+// returns the last error. The number of the attempt is set in the event
+// context that the caller shares, so an error handler of the caller sees the
+// last attempt. The attempts continue the count of the delivery attempt that
+// the transport reported.
+func WithRetry(config *RetryConfig) eventv1.Middleware {
+	settings := *config
+	// prepare the defaults
+	if settings.MaxAttempts <= 0 {
+		settings.MaxAttempts = 3
+	}
+
+	if settings.InitialInterval <= 0 {
+		settings.InitialInterval = 100 * time.Millisecond
+	}
+
+	if settings.MaxInterval <= 0 {
+		settings.MaxInterval = 10 * time.Second
+	}
+
+	if settings.Multiplier < 1 {
+		settings.Multiplier = 2
+	}
+
+	if settings.Jitter == 0 {
+		settings.Jitter = 0.2
+	}
+
+	innerFn := func(next eventv1.EventHandler) eventv1.EventHandler {
+		fn := func(ctx context.Context, event *eventv1.Event) error {
+			start := time.Now()
+			interval := settings.InitialInterval
+
+			ectx, ok := eventv1.FromContext(ctx)
+			if !ok || ectx.ID != event.GetId() {
+				ectx = eventv1.NewEventContext(event)
+				// add the event attributes to the context
+				ctx = eventv1.NewContext(ctx, ectx)
+			}
+
+			base := max(ectx.Attempt, 1)
+
+			for attempt := 1; ; attempt++ {
+				ectx.Attempt = base + attempt - 1
+
+				// execute the handler
+				err := next.HandleEvent(ctx, event)
+				if err == nil {
+					return nil
+				}
+
+				switch eventv1.KindOf(err) {
+				case eventv1.ErrorKindPermanent, eventv1.ErrorKindSkip:
+					return err
+				}
+
+				if attempt >= settings.MaxAttempts {
+					return err
+				}
+
+				delay := backoffDelay(interval, settings.Jitter)
+				if after, ok := eventv1.RetryAfterOf(err); ok && after > delay {
+					delay = after
+				}
+
+				if settings.MaxElapsedTime > 0 && time.Since(start)+delay > settings.MaxElapsedTime {
+					return err
+				}
+
+				if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+					return err
+				}
+
+				logger := slogr.FromContext(ctx)
+				logger.WarnContext(ctx, "retry an event",
+					slog.Int("attempt", ectx.Attempt),
+					slog.Duration("delay", delay),
+					slogr.Error(err),
+				)
+
+				timer := time.NewTimer(delay)
+
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				case <-timer.C:
+				}
+
+				interval = min(time.Duration(float64(interval)*settings.Multiplier), settings.MaxInterval)
+			}
+		}
+
+		return eventv1.EventHandlerFunc(fn)
+	}
+
+	return innerFn
+}
+
+// backoffDelay returns the interval randomized by the given jitter.
+func backoffDelay(interval time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return interval
+	}
+
+	delta := jitter * (2*rand.Float64() - 1)
+	// done!
+	return time.Duration(float64(interval) * (1 + delta))
+}
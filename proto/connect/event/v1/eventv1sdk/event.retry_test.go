@@ -0,0 +1,164 @@
+package eventv1sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1fake"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func newTestRetryConfig() *eventv1sdk.RetryConfig {
+	return &eventv1sdk.RetryConfig{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+		Jitter:          -1,
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	var attempts []int
+
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(ctx context.Context, _ *eventv1.Event) error {
+		attempts = append(attempts, eventv1.GetEventAttempt(ctx))
+		if len(attempts) < 3 {
+			return errors.New("failure")
+		}
+
+		return nil
+	}), eventv1sdk.WithRetry(newTestRetryConfig()))
+
+	if err := handler.HandleEvent(context.Background(), newTestEvent(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(attempts) != 3 || attempts[0] != 1 || attempts[2] != 3 {
+		t.Errorf("got attempts %v, want [1 2 3]", attempts)
+	}
+}
+
+func TestWithRetryGiveUp(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		config   func(*eventv1sdk.RetryConfig)
+		attempts int
+	}{
+		{"max attempts", errors.New("failure"), func(*eventv1sdk.RetryConfig) {}, 3},
+		{"permanent", eventv1.Permanent(errors.New("failure")), func(*eventv1sdk.RetryConfig) {}, 1},
+		{"skip", eventv1.Skip(nil), func(*eventv1sdk.RetryConfig) {}, 1},
+		{"max elapsed time", errors.New("failure"), func(config *eventv1sdk.RetryConfig) {
+			config.InitialInterval = time.Hour
+			config.MaxElapsedTime = time.Minute
+		}, 1},
+		{"retry after", eventv1.Retryable(errors.New("failure"), time.Hour), func(config *eventv1sdk.RetryConfig) {
+			config.MaxElapsedTime = time.Minute
+		}, 1},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			config := newTestRetryConfig()
+			item.config(config)
+
+			next := &eventv1fake.FakeEventHandler{}
+			next.HandleEventReturns(item.err)
+
+			handler := eventv1.Chain(next, eventv1sdk.WithRetry(config))
+
+			if err := handler.HandleEvent(context.Background(), newTestEvent(t)); !errors.Is(err, item.err) {
+				t.Errorf("got %v, want %v", err, item.err)
+			}
+
+			if got := next.HandleEventCallCount(); got != item.attempts {
+				t.Errorf("got %d attempts, want %d", got, item.attempts)
+			}
+		})
+	}
+}
+
+func TestWithRetryContextDeadline(t *testing.T) {
+	next := &eventv1fake.FakeEventHandler{}
+	next.HandleEventReturns(errors.New("failure"))
+
+	config := newTestRetryConfig()
+	config.InitialInterval = time.Second
+
+	handler := eventv1.Chain(next, eventv1sdk.WithRetry(config))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	if err := handler.HandleEvent(ctx, newTestEvent(t)); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("the middleware waits %v past the deadline", elapsed)
+	}
+
+	if got := next.HandleEventCallCount(); got != 1 {
+		t.Errorf("got %d attempts, want 1", got)
+	}
+}
+
+func TestWithRetryDeliveryAttempt(t *testing.T) {
+	event := newTestEvent(t)
+
+	ectx := eventv1.NewEventContext(event)
+	ectx.Attempt = 4
+
+	var attempts []int
+
+	handler := eventv1.Chain(eventv1.EventHandlerFunc(func(ctx context.Context, _ *eventv1.Event) error {
+		attempts = append(attempts, eventv1.GetEventAttempt(ctx))
+		return errors.New("failure")
+	}), eventv1sdk.WithRetry(newTestRetryConfig()))
+
+	_ = handler.HandleEvent(eventv1.NewContext(context.Background(), ectx), event)
+
+	if len(attempts) != 3 || attempts[0] != 4 || attempts[2] != 6 {
+		t.Errorf("got attempts %v, want [4 5 6]", attempts)
+	}
+
+	if ectx.Attempt != 6 {
+		t.Errorf("the caller sees attempt %d, want 6", ectx.Attempt)
+	}
+}
+
+func TestWithRetryDeadLetterAttempt(t *testing.T) {
+	client := &eventv1fake.FakeEventServiceClient{}
+
+	calls := 0
+
+	service := &eventv1sdk.EventService{
+		EventHandler: eventv1.Chain(eventv1.EventHandlerFunc(func(context.Context, *eventv1.Event) error {
+			calls++
+			if calls < 2 {
+				return errors.New("failure")
+			}
+
+			return eventv1.Permanent(errors.New("cause"))
+		}), eventv1sdk.WithRetry(newTestRetryConfig())),
+		ErrorHandler: &eventv1sdk.DeadLetterErrorHandler{EventServiceClient: client},
+	}
+
+	if _, err := service.PushEvent(context.Background(), &eventv1.PushEventRequest{Event: newTestEvent(t)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if client.PushEventCallCount() != 1 {
+		t.Fatalf("got %d dead-letter events, want 1", client.PushEventCallCount())
+	}
+
+	_, args := client.PushEventArgsForCall(0)
+
+	if got, _ := eventv1.GetExtension[int](args.GetEvent(), eventv1sdk.DeadLetterAttemptKey); got != 2 {
+		t.Errorf("got attempt %d, want 2", got)
+	}
+}
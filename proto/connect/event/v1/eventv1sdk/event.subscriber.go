@@ -0,0 +1,181 @@
+package eventv1sdk
+
+import (
+	context "context"
+	fmt "fmt"
+	slog "log/slog"
+	time "time"
+
+	pubsub "cloud.google.com/go/pubsub"
+	slogr "github.com/ralch/slogr"
+	option "google.golang.org/api/option"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+)
+
+var (
+	// ErrMissingConfig is returned by NewEventPubsubSubscriber when the config argument is not provided.
+	ErrMissingConfig = fmt.Errorf("no config")
+	// ErrMissingSubscription is returned by NewEventPubsubSubscriber when the subscription argument is not provided.
+	ErrMissingSubscription = fmt.Errorf("no subscription")
+	// ErrMissingEventService is returned by NewEventPubsubSubscriber when the event service argument is not provided.
+	ErrMissingEventService = fmt.Errorf("no event service")
+)
+
+// EventPubsubSubscriberConfig represents a configuration for the EventPubsubSubscriber.
+type EventPubsubSubscriberConfig struct {
+	// Project is the Google Cloud Project
+	Project string
+	// Subscription is the Google Pub/Sub Subscription
+	Subscription string
+	// Options contains the client Options
+	Options []option.ClientOption
+	// ReceiveSettings contains the flow control and the ack deadline
+	// extension settings of the subscriber. The ack deadline of a message is
+	// extended while the EventService handles it, up to MaxExtension. It
+	// defaults to pubsub.DefaultReceiveSettings.
+	ReceiveSettings *pubsub.ReceiveSettings
+	// EventService contains an instance of cloud.event.v1.EventService service.
+	EventService eventv1.EventService
+	// ErrorHandler receives the messages that do not carry a valid event. It
+	// is optional. The message is delivered again when the ErrorHandler fails.
+	ErrorHandler eventv1.ErrorHandler
+	// ShutdownTimeout is the time that the EventService has to handle the
+	// pending events after the Receive context is done. Their context is
+	// cancelled afterwards. It defaults to 30 seconds.
+	ShutdownTimeout time.Duration
+}
+
+// EventPubsubSubscriber receives the events from a Google Pub/Sub subscription
+// and pushes them to an EventService. A message is acknowledged when the
+// EventService succeeds and it is delivered again when the EventService fails.
+// A message that does not carry a valid event fails permanently, so it is
+// acknowledged and passed to the optional ErrorHandler.
+type EventPubsubSubscriber struct {
+	client       *pubsub.Client
+	subscription *pubsub.Subscription
+	service      eventv1.EventService
+	errorHandler eventv1.ErrorHandler
+	timeout      time.Duration
+}
+
+// NewEventPubsubSubscriber creates a new EventPubsubSubscriber.
+func NewEventPubsubSubscriber(ctx context.Context, config *EventPubsubSubscriberConfig) (*EventPubsubSubscriber, error) {
+	if config == nil {
+		return nil, ErrMissingConfig
+	}
+
+	if config.Project == "" {
+		return nil, ErrMissingProject
+	}
+
+	if config.Subscription == "" {
+		return nil, ErrMissingSubscription
+	}
+
+	if config.EventService == nil {
+		return nil, ErrMissingEventService
+	}
+
+	// prepare the client
+	client, err := pubsub.NewClient(ctx, config.Project, config.Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// prepare the subscription
+	subscription := client.Subscription(config.Subscription)
+
+	if config.ReceiveSettings != nil {
+		subscription.ReceiveSettings = *config.ReceiveSettings
+	}
+
+	subscriber := &EventPubsubSubscriber{
+		client:       client,
+		subscription: subscription,
+		service:      config.EventService,
+		errorHandler: config.ErrorHandler,
+		timeout:      config.ShutdownTimeout,
+	}
+
+	if subscriber.timeout == 0 {
+		subscriber.timeout = 30 * time.Second
+	}
+
+	// done!
+	return subscriber, nil
+}
+
+// Receive receives the events until the context is done or a non-retryable
+// error occurs. The events that are being handled when the context is done
+// are given the ShutdownTimeout to complete, so Receive returns after the
+// EventService handles them or after their context is cancelled. Receive must
+// not be called concurrently.
+func (x *EventPubsubSubscriber) Receive(ctx context.Context) error {
+	// the handlers outlive the receive context for a graceful shutdown
+	hctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-hctx.Done():
+			return
+		}
+
+		timer := time.NewTimer(x.timeout)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			// cancel the pending handlers
+			cancel()
+		case <-hctx.Done():
+		}
+	}()
+
+	receiveFn := func(_ context.Context, message *pubsub.Message) {
+		if err := x.receive(hctx, message); err != nil {
+			message.Nack()
+			return
+		}
+
+		message.Ack()
+	}
+
+	return x.subscription.Receive(ctx, receiveFn)
+}
+
+func (x *EventPubsubSubscriber) receive(ctx context.Context, message *pubsub.Message) error {
+	logger := slogr.FromContext(ctx)
+	logger = logger.With(slog.String("message_id", message.ID))
+
+	ctx = slogr.WithContext(ctx, logger)
+
+	args, err := decodePubsubMessage(message.Attributes, message.Data)
+	if err != nil {
+		// the message is acknowledged unless it cannot be forwarded
+		return handleEventError(ctx, x.errorHandler, args.Event, err)
+	}
+
+	if message.DeliveryAttempt != nil {
+		ectx := eventv1.NewEventContext(args.Event)
+		ectx.Attempt = *message.DeliveryAttempt
+		// add the event attributes to the context
+		ctx = eventv1.NewContext(ctx, ectx)
+	}
+
+	// push the event
+	if _, err := x.service.PushEvent(ctx, args); err != nil {
+		logger.ErrorContext(ctx, "cannot push an event", slogr.Error(err))
+		return err
+	}
+
+	// done!
+	return nil
+}
+
+// Close closes the subscriber. It should be called after Receive returns.
+func (x *EventPubsubSubscriber) Close() error {
+	return x.client.Close()
+}
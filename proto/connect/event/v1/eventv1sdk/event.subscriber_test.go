@@ -0,0 +1,288 @@
+package eventv1sdk_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pstest "cloud.google.com/go/pubsub/pstest"
+	"google.golang.org/protobuf/proto"
+
+	eventv1 "github.com/connect-sdk/event-api/proto/connect/event/v1"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1fake"
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func newTestPubsubSubscriber(t *testing.T, config *eventv1sdk.EventPubsubSubscriberConfig) (*pstest.Server, *eventv1sdk.EventPubsubSubscriber) {
+	t.Helper()
+
+	server, options := newTestPubsubServer(t)
+
+	config.Project = "test"
+	config.Subscription = "events"
+	config.Options = options
+
+	subscriber, err := eventv1sdk.NewEventPubsubSubscriber(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { subscriber.Close() })
+
+	return server, subscriber
+}
+
+// receive runs the subscriber in the background. The returned function stops
+// it and returns the error of Receive.
+func receive(t *testing.T, subscriber *eventv1sdk.EventPubsubSubscriber) func() error {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- subscriber.Receive(ctx)
+	}()
+
+	var (
+		once sync.Once
+		err  error
+	)
+
+	stop := func() error {
+		once.Do(func() {
+			cancel()
+			err = <-done
+		})
+
+		return err
+	}
+
+	t.Cleanup(func() { _ = stop() })
+
+	return stop
+}
+
+func publish(t *testing.T, server *pstest.Server, attributes map[string]string, data []byte) string {
+	t.Helper()
+
+	return server.Publish("projects/test/topics/events", data, attributes)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); !condition(); {
+		if time.Now().After(deadline) {
+			t.Fatal("the condition is not met")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func isNacked(message *pstest.Message) bool {
+	for _, modack := range message.Modacks {
+		if modack.AckDeadline == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestNewEventPubsubSubscriber(t *testing.T) {
+	service := &eventv1fake.FakeEventService{}
+
+	cases := []struct {
+		name   string
+		config *eventv1sdk.EventPubsubSubscriberConfig
+		want   error
+	}{
+		{"nil config", nil, eventv1sdk.ErrMissingConfig},
+		{"missing project", &eventv1sdk.EventPubsubSubscriberConfig{Subscription: "events", EventService: service}, eventv1sdk.ErrMissingProject},
+		{"missing subscription", &eventv1sdk.EventPubsubSubscriberConfig{Project: "test", EventService: service}, eventv1sdk.ErrMissingSubscription},
+		{"missing event service", &eventv1sdk.EventPubsubSubscriberConfig{Project: "test", Subscription: "events"}, eventv1sdk.ErrMissingEventService},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			if _, err := eventv1sdk.NewEventPubsubSubscriber(context.Background(), item.config); !errors.Is(err, item.want) {
+				t.Errorf("got %v, want %v", err, item.want)
+			}
+		})
+	}
+}
+
+func TestEventPubsubSubscriberAck(t *testing.T) {
+	service := &eventv1fake.FakeEventService{}
+
+	server, subscriber := newTestPubsubSubscriber(t, &eventv1sdk.EventPubsubSubscriberConfig{
+		EventService: service,
+	})
+
+	event := newTestEvent(t)
+	args := &eventv1.PushEventRequest{Event: event}
+	id := publish(t, server, args.GetTypedAttributes(), args.GetData())
+
+	stop := receive(t, subscriber)
+
+	waitFor(t, func() bool { return server.Message(id).Acks == 1 })
+
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if service.PushEventCallCount() != 1 {
+		t.Fatalf("got %d pushed events, want 1", service.PushEventCallCount())
+	}
+
+	if _, args := service.PushEventArgsForCall(0); !proto.Equal(args.Event, event) {
+		t.Errorf("got %v, want %v", args.Event, event)
+	}
+}
+
+func TestEventPubsubSubscriberNack(t *testing.T) {
+	service := &eventv1fake.FakeEventService{}
+	service.PushEventReturns(nil, errors.New("unavailable"))
+
+	server, subscriber := newTestPubsubSubscriber(t, &eventv1sdk.EventPubsubSubscriberConfig{
+		EventService: service,
+	})
+
+	args := &eventv1.PushEventRequest{Event: newTestEvent(t)}
+	id := publish(t, server, args.GetTypedAttributes(), args.GetData())
+
+	receive(t, subscriber)
+
+	waitFor(t, func() bool { return isNacked(server.Message(id)) })
+
+	if got := server.Message(id).Acks; got != 0 {
+		t.Errorf("got %d acks, want 0", got)
+	}
+}
+
+func TestEventPubsubSubscriberMalformed(t *testing.T) {
+	attributes := map[string]string{"ce-id": "1", "ce-datacontenttype": eventv1.ContentTypeCloudEventsProtobuf}
+
+	t.Run("forward", func(t *testing.T) {
+		service := &eventv1fake.FakeEventService{}
+		errorHandler := &eventv1fake.FakeErrorHandler{}
+
+		server, subscriber := newTestPubsubSubscriber(t, &eventv1sdk.EventPubsubSubscriberConfig{
+			EventService: service,
+			ErrorHandler: errorHandler,
+		})
+
+		id := publish(t, server, attributes, []byte("raw"))
+
+		receive(t, subscriber)
+
+		waitFor(t, func() bool { return server.Message(id).Acks == 1 })
+
+		if service.PushEventCallCount() != 0 {
+			t.Error("a malformed message is pushed")
+		}
+
+		if errorHandler.HandleErrorCallCount() != 1 {
+			t.Fatalf("got %d forwarded messages, want 1", errorHandler.HandleErrorCallCount())
+		}
+
+		_, event, err := errorHandler.HandleErrorArgsForCall(0)
+		if kind := eventv1.KindOf(err); kind != eventv1.ErrorKindPermanent {
+			t.Errorf("got kind %v, want %v", kind, eventv1.ErrorKindPermanent)
+		}
+
+		if got := string(event.GetBinaryData()); got != "raw" {
+			t.Errorf("got data %q, want the raw message data", got)
+		}
+	})
+
+	t.Run("forward failure", func(t *testing.T) {
+		errorHandler := &eventv1fake.FakeErrorHandler{}
+		errorHandler.HandleErrorReturns(errors.New("unavailable"))
+
+		server, subscriber := newTestPubsubSubscriber(t, &eventv1sdk.EventPubsubSubscriberConfig{
+			EventService: &eventv1fake.FakeEventService{},
+			ErrorHandler: errorHandler,
+		})
+
+		id := publish(t, server, attributes, []byte("raw"))
+
+		receive(t, subscriber)
+
+		waitFor(t, func() bool { return isNacked(server.Message(id)) })
+
+		if got := server.Message(id).Acks; got != 0 {
+			t.Errorf("got %d acks, want 0", got)
+		}
+	})
+}
+
+func TestEventPubsubSubscriberShutdown(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var cause error
+
+	service := &eventv1fake.FakeEventService{}
+	service.PushEventStub = func(ctx context.Context, _ *eventv1.PushEventRequest) (*eventv1.PushEventResponse, error) {
+		close(started)
+
+		select {
+		case <-release:
+		case <-ctx.Done():
+			cause = ctx.Err()
+		}
+
+		return &eventv1.PushEventResponse{}, nil
+	}
+
+	t.Run("graceful", func(t *testing.T) {
+		server, subscriber := newTestPubsubSubscriber(t, &eventv1sdk.EventPubsubSubscriberConfig{
+			EventService:    service,
+			ShutdownTimeout: time.Minute,
+		})
+
+		args := &eventv1.PushEventRequest{Event: newTestEvent(t)}
+		publish(t, server, args.GetTypedAttributes(), args.GetData())
+
+		stop := receive(t, subscriber)
+		<-started
+
+		time.AfterFunc(50*time.Millisecond, func() { close(release) })
+
+		if err := stop(); err != nil {
+			t.Fatal(err)
+		}
+
+		if cause != nil {
+			t.Errorf("the handler is cancelled: %v", cause)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		started = make(chan struct{})
+		release = make(chan struct{})
+
+		server, subscriber := newTestPubsubSubscriber(t, &eventv1sdk.EventPubsubSubscriberConfig{
+			EventService:    service,
+			ShutdownTimeout: 50 * time.Millisecond,
+		})
+
+		args := &eventv1.PushEventRequest{Event: newTestEvent(t)}
+		publish(t, server, args.GetTypedAttributes(), args.GetData())
+
+		stop := receive(t, subscriber)
+		<-started
+
+		if err := stop(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !errors.Is(cause, context.Canceled) {
+			t.Errorf("got %v, want %v", cause, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package eventv1sdk
+
+import (
+	context "context"
+	http "net/http"
+	url "net/url"
+	strconv "strconv"
+	strings "strings"
+	time "time"
+
+	slogr "github.com/ralch/slogr"
+)
+
+// DefaultMaxConfirmations is the default number of callback confirmations
+// that WithWebhook runs at the same time.
+const DefaultMaxConfirmations = 10
+
+// WebhookConfig represents a configuration for the CloudEvents HTTP Webhook
+// abuse protection handshake.
+type WebhookConfig struct {
+	// AllowedOrigins contains the origins that may deliver events. The "*"
+	// origin allows every origin.
+	AllowedOrigins []string
+	// AllowedRate is the number of requests per minute that an origin may
+	// deliver. Zero does not limit the rate.
+	AllowedRate int
+	// Client is the HTTP client that confirms the callback. It defaults to
+	// http.DefaultClient.
+	Client *http.Client
+	// Timeout is the timeout of the callback confirmation. It defaults to 30 seconds.
+	Timeout time.Duration
+	// AllowedCallbackHosts contains the hosts, other than the origin, that
+	// may receive the callback confirmation. By default the host of the
+	// callback URL must be the origin. The origin is not trusted when
+	// AllowedOrigins contains "*", so the host must then be one of these.
+	AllowedCallbackHosts []string
+	// MaxConfirmations is the number of callback confirmations that run at
+	// the same time. It defaults to DefaultMaxConfirmations.
+	MaxConfirmations int
+}
+
+// WithWebhook set up the CloudEvents HTTP Webhook validation handshake. It
+// answers the OPTIONS requests that carry the WebHook-Request-Origin header and
+// passes every other request to the next handler. When the request carries the
+// WebHook-Request-Callback header the handshake is confirmed asynchronously by
+// a GET request to the callback URL. The host of the callback URL must be the
+// origin or one of the AllowedCallbackHosts, or only one of the
+// AllowedCallbackHosts when every origin is allowed, otherwise the request is
+// rejected with http.StatusBadRequest. When MaxConfirmations confirmations are running
+// the request is rejected with http.StatusServiceUnavailable.
+func WithWebhook(config *WebhookConfig) func(http.Handler) http.Handler {
+	limit := config.MaxConfirmations
+	if limit <= 0 {
+		limit = DefaultMaxConfirmations
+	}
+
+	confirmations := make(chan struct{}, limit)
+
+	innerFn := func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("WebHook-Request-Origin")
+
+			if r.Method != http.MethodOptions || origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !config.allows(origin) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			callback := r.Header.Get("WebHook-Request-Callback")
+			// the callback must not target arbitrary hosts
+			if callback != "" && !config.allowsCallback(origin, callback) {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+
+			if callback != "" {
+				select {
+				case confirmations <- struct{}{}:
+				default:
+					http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+					return
+				}
+			}
+
+			header := w.Header()
+			header.Set("Allow", http.MethodPost)
+			header.Set("WebHook-Allowed-Origin", origin)
+			header.Set("WebHook-Allowed-Rate", config.rate())
+
+			if callback != "" {
+				ctx := context.WithoutCancel(r.Context())
+				// confirm the handshake
+				go func() {
+					defer func() { <-confirmations }()
+					config.confirm(ctx, callback)
+				}()
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+
+	return innerFn
+}
+
+func (x *WebhookConfig) allows(origin string) bool {
+	for _, allowed := range x.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (x *WebhookConfig) allowsAny() bool {
+	for _, allowed := range x.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (x *WebhookConfig) allowsCallback(origin, callback string) bool {
+	uri, err := url.Parse(callback)
+	if err != nil || (uri.Scheme != "http" && uri.Scheme != "https") {
+		return false
+	}
+
+	host := uri.Hostname()
+	// any sender may claim an origin that "*" allows
+	if strings.EqualFold(host, origin) && !x.allowsAny() {
+		return true
+	}
+
+	for _, allowed := range x.AllowedCallbackHosts {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (x *WebhookConfig) rate() string {
+	if x.AllowedRate > 0 {
+		return strconv.Itoa(x.AllowedRate)
+	}
+
+	return "*"
+}
+
+func (x *WebhookConfig) confirm(ctx context.Context, callback string) {
+	timeout := x.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	logger := slogr.FromContext(ctx)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, callback, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "cannot confirm the webhook callback", slogr.Error(err))
+		return
+	}
+
+	request.Header.Set("WebHook-Allowed-Rate", x.rate())
+
+	client := x.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		logger.ErrorContext(ctx, "cannot confirm the webhook callback", slogr.Error(err))
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		logger.ErrorContext(ctx, "cannot confirm the webhook callback: "+response.Status)
+	}
+}
@@ -0,0 +1,228 @@
+package eventv1sdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/connect-sdk/event-api/proto/connect/event/v1/eventv1sdk"
+)
+
+func newTestWebhook(t *testing.T, config *eventv1sdk.WebhookConfig) (http.Handler, *bool) {
+	t.Helper()
+
+	called := false
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return eventv1sdk.WithWebhook(config)(next), &called
+}
+
+func newTestCallback(t *testing.T) (*httptest.Server, chan http.Header) {
+	t.Helper()
+
+	confirmed := make(chan http.Header, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		confirmed <- r.Header.Clone()
+	}))
+	t.Cleanup(server.Close)
+
+	return server, confirmed
+}
+
+func TestWithWebhookHandshake(t *testing.T) {
+	handler, called := newTestWebhook(t, &eventv1sdk.WebhookConfig{
+		AllowedOrigins: []string{"sender.example.com"},
+		AllowedRate:    120,
+	})
+
+	r := httptest.NewRequest(http.MethodOptions, "/", nil)
+	r.Header.Set("WebHook-Request-Origin", "sender.example.com")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusOK)
+	}
+
+	if got := w.Header().Get("WebHook-Allowed-Origin"); got != "sender.example.com" {
+		t.Errorf("got allowed origin %q", got)
+	}
+
+	if got := w.Header().Get("WebHook-Allowed-Rate"); got != "120" {
+		t.Errorf("got allowed rate %q, want 120", got)
+	}
+
+	if *called {
+		t.Error("the handshake reached the next handler")
+	}
+}
+
+func TestWithWebhookForbiddenOrigin(t *testing.T) {
+	handler, _ := newTestWebhook(t, &eventv1sdk.WebhookConfig{
+		AllowedOrigins: []string{"sender.example.com"},
+	})
+
+	r := httptest.NewRequest(http.MethodOptions, "/", nil)
+	r.Header.Set("WebHook-Request-Origin", "attacker.example.com")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestWithWebhookPassThrough(t *testing.T) {
+	handler, called := newTestWebhook(t, &eventv1sdk.WebhookConfig{})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if !*called || w.Code != http.StatusNoContent {
+		t.Errorf("got status %d, want the next handler", w.Code)
+	}
+}
+
+func TestWithWebhookCallback(t *testing.T) {
+	server, confirmed := newTestCallback(t)
+
+	uri, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		origin  string
+		origins []string
+		hosts   []string
+	}{
+		{"origin host", uri.Hostname(), []string{uri.Hostname()}, nil},
+		{"allowed host", "sender.example.com", []string{"*"}, []string{uri.Hostname()}},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			handler, _ := newTestWebhook(t, &eventv1sdk.WebhookConfig{
+				AllowedOrigins:       item.origins,
+				AllowedCallbackHosts: item.hosts,
+				Client:               server.Client(),
+			})
+
+			r := httptest.NewRequest(http.MethodOptions, "/", nil)
+			r.Header.Set("WebHook-Request-Origin", item.origin)
+			r.Header.Set("WebHook-Request-Callback", server.URL+"/confirm")
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("got status %d, want %d", w.Code, http.StatusOK)
+			}
+
+			select {
+			case header := <-confirmed:
+				if got := header.Get("WebHook-Allowed-Rate"); got != "*" {
+					t.Errorf("got allowed rate %q, want *", got)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("the callback is not confirmed")
+			}
+		})
+	}
+}
+
+func TestWithWebhookCallbackRejected(t *testing.T) {
+	server, confirmed := newTestCallback(t)
+
+	uri, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		origin   string
+		callback string
+	}{
+		{"other host", "sender.example.com", server.URL + "/confirm"},
+		{"any origin", uri.Hostname(), server.URL + "/confirm"},
+		{"other scheme", "sender.example.com", "file:///etc/passwd"},
+		{"invalid url", "sender.example.com", "http://[::1"},
+	}
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			handler, _ := newTestWebhook(t, &eventv1sdk.WebhookConfig{
+				AllowedOrigins: []string{"*"},
+				Client:         server.Client(),
+			})
+
+			r := httptest.NewRequest(http.MethodOptions, "/", nil)
+			r.Header.Set("WebHook-Request-Origin", item.origin)
+			r.Header.Set("WebHook-Request-Callback", item.callback)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("got status %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+
+	select {
+	case <-confirmed:
+		t.Error("a rejected callback is confirmed")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWithWebhookCallbackLimit(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	uri, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler, _ := newTestWebhook(t, &eventv1sdk.WebhookConfig{
+		AllowedOrigins:   []string{uri.Hostname()},
+		Client:           server.Client(),
+		MaxConfirmations: 1,
+	})
+
+	handshake := func() int {
+		r := httptest.NewRequest(http.MethodOptions, "/", nil)
+		r.Header.Set("WebHook-Request-Origin", uri.Hostname())
+		r.Header.Set("WebHook-Request-Callback", server.URL+"/confirm")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		return w.Code
+	}
+
+	if got := handshake(); got != http.StatusOK {
+		t.Fatalf("got status %d, want %d", got, http.StatusOK)
+	}
+
+	if got := handshake(); got != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, want %d", got, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,10 @@
+package eventv1
+
+// UnregisterAttributeKind removes the registered type of the named attribute,
+// so the tests do not leak registrations into each other.
+func UnregisterAttributeKind(name string) {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+
+	delete(kinds, name)
+}